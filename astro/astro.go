@@ -0,0 +1,54 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+
+// Package astro calculates the phases of the moon and the times of sunrise and
+// sunset. The formulas are simplified versions of those in Astronomical
+// Algorithms by Jean Meeus and are accurate to within a few minutes, which is
+// plenty for a calendar.
+package astro
+
+import (
+	"math"
+	"time"
+)
+
+// j2000 is the Julian day of 2000-01-01 12:00 UTC.
+const j2000 = 2451545.0
+
+// julianDay returns the Julian day, a count of days since noon on 1 January
+// 4713 BCE, of time t.
+func julianDay(t time.Time) float64 {
+	return float64(t.Unix())/86400 + 2440587.5
+}
+
+// fromJulianDay returns the time of a Julian day.
+func fromJulianDay(jd float64) time.Time {
+	seconds := (jd - 2440587.5) * 86400
+	return time.Unix(0, 0).Add(time.Duration(seconds * float64(time.Second)))
+}
+
+// sin of an angle in degrees.
+func sin(deg float64) float64 {
+	return math.Sin(deg * math.Pi / 180)
+}
+
+// cos of an angle in degrees.
+func cos(deg float64) float64 {
+	return math.Cos(deg * math.Pi / 180)
+}
+
+// normalize an angle in degrees to [0, 360).
+func normalize(deg float64) float64 {
+	deg = math.Mod(deg, 360)
+	if deg < 0 {
+		deg += 360
+	}
+	return deg
+}
+
+// startOfDay returns midnight at the start of the day of time t and midnight
+// at the start of the next day, both in the location of t.
+func startOfDay(t time.Time) (time.Time, time.Time) {
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return start, start.AddDate(0, 0, 1)
+}
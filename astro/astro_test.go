@@ -0,0 +1,124 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package astro
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMoonPhase(t *testing.T) {
+	auckland, err := time.LoadLocation("Pacific/Auckland")
+	if err != nil {
+		t.Fatalf("failed loading Pacific/Auckland timezone: %v", err)
+	}
+
+	type test struct {
+		day  time.Time
+		want Phase
+	}
+
+	tests := []test{
+		{day: time.Date(2023, time.January, 6, 0, 0, 0, 0, time.UTC), want: FullMoon},
+		// The same full moon was on the next day in New Zealand.
+		{day: time.Date(2023, time.January, 6, 0, 0, 0, 0, auckland), want: NoPhase},
+		{day: time.Date(2023, time.January, 7, 0, 0, 0, 0, auckland), want: FullMoon},
+		{day: time.Date(2023, time.January, 15, 0, 0, 0, 0, time.UTC), want: LastQuarter},
+		{day: time.Date(2023, time.January, 21, 0, 0, 0, 0, time.UTC), want: NewMoon},
+		{day: time.Date(2023, time.January, 28, 0, 0, 0, 0, time.UTC), want: FirstQuarter},
+		{day: time.Date(2023, time.January, 29, 0, 0, 0, 0, time.UTC), want: NoPhase},
+		{day: time.Date(2024, time.April, 8, 0, 0, 0, 0, time.UTC), want: NewMoon},
+	}
+
+	for _, tc := range tests {
+		if got := MoonPhase(tc.day); got != tc.want {
+			t.Fatalf(
+				"%v: want: %v, got: %v",
+				tc.day.Format("2006-01-02 MST"),
+				tc.want,
+				got,
+			)
+		}
+	}
+}
+
+func TestSun(t *testing.T) {
+	wellington, err := time.LoadLocation("Pacific/Auckland")
+	if err != nil {
+		t.Fatalf("failed loading Pacific/Auckland timezone: %v", err)
+	}
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("failed loading Europe/London timezone: %v", err)
+	}
+
+	type test struct {
+		day       time.Time
+		latitude  float64
+		longitude float64
+		rise      string
+		set       string
+	}
+
+	tests := []test{
+		{
+			day:       time.Date(2023, time.June, 21, 0, 0, 0, 0, wellington),
+			latitude:  -41.29,
+			longitude: 174.78,
+			rise:      "07:47",
+			set:       "16:58",
+		},
+		{
+			day:       time.Date(2023, time.June, 21, 0, 0, 0, 0, london),
+			latitude:  51.51,
+			longitude: -0.13,
+			rise:      "04:43",
+			set:       "21:21",
+		},
+	}
+
+	for _, tc := range tests {
+		d := Sun(tc.day, tc.latitude, tc.longitude)
+		for _, c := range []struct {
+			got  time.Time
+			want string
+		}{{d.Rise, tc.rise}, {d.Set, tc.set}} {
+			want, err := time.ParseInLocation(
+				"2006-01-02 15:04",
+				tc.day.Format("2006-01-02 ")+c.want,
+				tc.day.Location(),
+			)
+			if err != nil {
+				t.Fatalf("failed parsing time: %v", err)
+			}
+			if diff := c.got.Sub(want); diff > 3*time.Minute ||
+				diff < -3*time.Minute {
+				t.Fatalf("want: %v, got: %v", want, c.got)
+			}
+		}
+	}
+
+	tromso := time.Date(2023, time.June, 21, 0, 0, 0, 0, time.UTC)
+	if d := Sun(tromso, 69.65, 18.96); !d.PolarDay {
+		t.Fatalf("expected the midnight sun in Tromsø in June")
+	}
+	if d := Sun(tromso.AddDate(0, 6, 0), 69.65, 18.96); !d.PolarNight {
+		t.Fatalf("expected the polar night in Tromsø in December")
+	}
+}
+
+func TestSeasons(t *testing.T) {
+	want := [4]time.Time{
+		time.Date(2023, time.March, 20, 21, 24, 0, 0, time.UTC),
+		time.Date(2023, time.June, 21, 14, 57, 0, 0, time.UTC),
+		time.Date(2023, time.September, 23, 6, 50, 0, 0, time.UTC),
+		time.Date(2023, time.December, 22, 3, 27, 0, 0, time.UTC),
+	}
+	got := Seasons(2023)
+	for i := range want {
+		if diff := got[i].Sub(want[i]); diff > 2*time.Minute ||
+			diff < -2*time.Minute {
+			t.Fatalf("%v: want: %v, got: %v", SeasonNames[i], want[i], got[i])
+		}
+	}
+}
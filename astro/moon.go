@@ -0,0 +1,90 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package astro
+
+import "time"
+
+// Phase is a principal phase of the moon.
+type Phase uint8
+
+const (
+	// NoPhase is a day without a principal phase.
+	NoPhase Phase = iota
+	// NewMoon and the following phases are in the same order as the
+	// locale's phase names.
+	NewMoon
+	FirstQuarter
+	FullMoon
+	LastQuarter
+)
+
+// Glyph returns a symbol for the phase, such as "○" for a full moon.
+func (p Phase) Glyph() string {
+	switch p {
+	case NewMoon:
+		return "●"
+	case FirstQuarter:
+		return "◐"
+	case FullMoon:
+		return "○"
+	case LastQuarter:
+		return "◑"
+	}
+	return ""
+}
+
+// elongation returns how far the moon is ahead of the sun in ecliptic
+// longitude at time t, in degrees. It is 0 at new moon, 90 at first quarter,
+// 180 at full moon, and 270 at last quarter.
+func elongation(t time.Time) float64 {
+	T := (julianDay(t) - j2000) / 36525
+
+	// Sun.
+	L0 := 280.46646 + 36000.76983*T
+	M := 357.52911 + 35999.05029*T
+	sun := L0 +
+		1.914602*sin(M) +
+		0.019993*sin(2*M) +
+		0.000289*sin(3*M)
+
+	// Moon, using the largest periodic terms.
+	L := 218.3164477 + 481267.88123421*T
+	D := 297.8501921 + 445267.1114034*T
+	Mm := 134.9633964 + 477198.8675055*T
+	F := 93.2720950 + 483202.0175233*T
+	moon := L +
+		6.288774*sin(Mm) +
+		1.274027*sin(2*D-Mm) +
+		0.658314*sin(2*D) +
+		0.213618*sin(2*Mm) -
+		0.185116*sin(M) -
+		0.114332*sin(2*F) +
+		0.058793*sin(2*D-2*Mm) +
+		0.057066*sin(2*D-M-Mm) +
+		0.053322*sin(2*D+Mm) +
+		0.045758*sin(2*D-M) -
+		0.040923*sin(M-Mm) -
+		0.034720*sin(D) -
+		0.030383*sin(M+Mm)
+
+	return normalize(moon - sun)
+}
+
+// MoonPhase returns the principal phase of the moon which happens during the
+// day of time t in its location, if any.
+func MoonPhase(t time.Time) Phase {
+	start, end := startOfDay(t)
+	from := elongation(start)
+	to := elongation(end)
+	if to < from {
+		// The moon passed the sun.
+		return NewMoon
+	}
+	for _, p := range []Phase{FirstQuarter, FullMoon, LastQuarter} {
+		angle := float64(p-NewMoon) * 90
+		if from < angle && to >= angle {
+			return p
+		}
+	}
+	return NoPhase
+}
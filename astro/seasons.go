@@ -0,0 +1,81 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package astro
+
+import "time"
+
+// SeasonNames are the names of the equinoxes and solstices in the order they
+// happen in a year.
+var SeasonNames = [4]string{
+	"March equinox",
+	"June solstice",
+	"September equinox",
+	"December solstice",
+}
+
+// meanSeasons are the coefficients of a polynomial giving the Julian day of
+// each mean equinox and solstice in the years 1000 to 3000.
+var meanSeasons = [4][5]float64{
+	{2451623.80984, 365242.37404, 0.05169, -0.00411, -0.00057},
+	{2451716.56767, 365241.62603, 0.00325, 0.00888, -0.00030},
+	{2451810.21715, 365242.01767, -0.11575, 0.00337, 0.00078},
+	{2451900.05952, 365242.74049, -0.06223, -0.00823, 0.00032},
+}
+
+// seasonTerms are the periodic terms correcting the mean equinoxes and
+// solstices, as amplitude, phase, and rate.
+var seasonTerms = [24][3]float64{
+	{485, 324.96, 1934.136},
+	{203, 337.23, 32964.467},
+	{199, 342.08, 20.186},
+	{182, 27.85, 445267.112},
+	{156, 73.14, 45036.886},
+	{136, 171.52, 22518.443},
+	{77, 222.54, 65928.934},
+	{74, 296.72, 3034.906},
+	{70, 243.58, 9037.513},
+	{58, 119.81, 33718.147},
+	{52, 297.17, 150.678},
+	{50, 21.02, 2281.232},
+	{45, 247.54, 29929.562},
+	{44, 325.15, 31555.956},
+	{29, 60.93, 4443.417},
+	{18, 155.12, 67555.328},
+	{17, 288.79, 4562.452},
+	{16, 198.04, 62894.029},
+	{14, 199.76, 31436.921},
+	{12, 95.39, 14577.848},
+	{12, 287.11, 31931.756},
+	{12, 320.81, 34777.259},
+	{9, 227.73, 1222.114},
+	{8, 15.45, 16859.074},
+}
+
+// Seasons returns the times of the March equinox, June solstice, September
+// equinox, and December solstice in a year.
+func Seasons(year int) [4]time.Time {
+	var times [4]time.Time
+	y := float64(year-2000) / 1000
+	for i, c := range meanSeasons {
+		jd := c[0] + c[1]*y + c[2]*y*y + c[3]*y*y*y + c[4]*y*y*y*y
+		T := (jd - j2000) / 36525
+		W := 35999.373*T - 2.47
+		dl := 1 + 0.0334*cos(W) + 0.0007*cos(2*W)
+		var S float64
+		for _, term := range seasonTerms {
+			S += term[0] * cos(term[1]+term[2]*T)
+		}
+		jd += 0.00001 * S / dl
+		times[i] = fromJulianDay(jd - deltaT(year)/86400).Round(time.Minute)
+	}
+	return times
+}
+
+// deltaT estimates the difference in seconds between the uniform time used by
+// the formulas and the time kept by clocks, which follows the slowing rotation
+// of the earth. The estimate is from NASA for the years 2005 to 2050 and is
+// still within a minute or two for a century either side.
+func deltaT(year int) float64 {
+	t := float64(year - 2000)
+	return 62.92 + 0.32217*t + 0.005589*t*t
+}
@@ -0,0 +1,55 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package astro
+
+import (
+	"math"
+	"time"
+)
+
+// Daylight describes when the sun rises and sets on a day.
+type Daylight struct {
+	Rise time.Time
+	Set  time.Time
+	// Length of time between sunrise and sunset.
+	Length time.Duration
+	// PolarDay is true when the sun never sets and PolarNight is true when it
+	// never rises. Rise and Set are zero on these days.
+	PolarDay   bool
+	PolarNight bool
+}
+
+// Sun returns the sunrise and sunset on the day of time t, in its location,
+// at a given latitude and longitude in degrees. North and east are positive.
+func Sun(t time.Time, latitude, longitude float64) Daylight {
+	noon := time.Date(t.Year(), t.Month(), t.Day(), 12, 0, 0, 0, time.UTC)
+	n := math.Round(julianDay(noon) - j2000)
+
+	// Mean solar noon at this longitude.
+	mean := n - longitude/360
+	M := normalize(357.5291 + 0.98560028*mean)
+	C := 1.9148*sin(M) + 0.02*sin(2*M) + 0.0003*sin(3*M)
+	lambda := normalize(M + C + 180 + 102.9372)
+	transit := j2000 + mean + 0.0053*sin(M) - 0.0069*sin(2*lambda)
+
+	declination := math.Asin(sin(lambda)*sin(23.44)) * 180 / math.Pi
+	// The sun is considered up when its upper edge clears the horizon,
+	// including refraction, at 0.833 degrees below it.
+	cosHourAngle := (sin(-0.833) - sin(latitude)*sin(declination)) /
+		(cos(latitude) * cos(declination))
+	switch {
+	case cosHourAngle > 1:
+		return Daylight{PolarNight: true}
+	case cosHourAngle < -1:
+		return Daylight{PolarDay: true, Length: 24 * time.Hour}
+	}
+	hourAngle := math.Acos(cosHourAngle) * 180 / math.Pi
+
+	rise := fromJulianDay(transit - hourAngle/360).In(t.Location())
+	set := fromJulianDay(transit + hourAngle/360).In(t.Location())
+	return Daylight{
+		Rise:   rise,
+		Set:    set,
+		Length: set.Sub(rise),
+	}
+}
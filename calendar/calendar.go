@@ -3,22 +3,34 @@
 package calendar
 
 import (
+	"fmt"
+	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	"git.sr.ht/~kota/calendar/clock"
 	"git.sr.ht/~kota/calendar/config"
+	"git.sr.ht/~kota/calendar/countdown"
 	"git.sr.ht/~kota/calendar/date"
 	"git.sr.ht/~kota/calendar/holiday"
+	"git.sr.ht/~kota/calendar/index"
 	"git.sr.ht/~kota/calendar/keyword"
+	"git.sr.ht/~kota/calendar/locale"
+	"git.sr.ht/~kota/calendar/mark"
 	"git.sr.ht/~kota/calendar/month"
 	"git.sr.ht/~kota/calendar/note"
 	"git.sr.ht/~kota/calendar/preview"
-	"github.com/atotto/clipboard"
+	"git.sr.ht/~kota/calendar/prompt"
+	"git.sr.ht/~kota/calendar/status"
+	"git.sr.ht/~kota/calendar/workday"
+	"git.sr.ht/~kota/calendar/yank"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
 )
 
 // previewMode describes if the preview is shown, focused, or hidden.
@@ -36,6 +48,30 @@ const (
 	previewModeHidden
 )
 
+const (
+	// promptGoto is the id of the go-to prompt.
+	promptGoto = "goto"
+	// promptExport is the id of the prompt asking where to export a range of
+	// notes.
+	promptExport = "export"
+	// promptSearch is the id of the prompt asking for text to search notes
+	// for.
+	promptSearch = "search"
+)
+
+// pending describes a key press which is waiting for another key, such as the
+// name of a mark.
+type pending uint8
+
+const (
+	// pendingNone is when no key press is waiting.
+	pendingNone pending = iota
+	// pendingMark is when the next key names a mark to set.
+	pendingMark
+	// pendingJumpMark is when the next key names a mark to jump to.
+	pendingJumpMark
+)
+
 // editorFinishedMsg is a tea.Msg returned when the spawned editor process
 // returns.
 type editorFinishedMsg struct{ err error }
@@ -44,24 +80,70 @@ type editorFinishedMsg struct{ err error }
 // thin wrapper for the month elements. It creates and destroys them based on
 // the size of the window.
 type Calendar struct {
-	today       time.Time
-	selected    time.Time
-	config      *config.Config
-	style       lipgloss.Style
-	months      []month.Month
+	today      time.Time
+	selected   time.Time
+	anchor     time.Time
+	ranging    bool
+	picking    bool
+	pending    pending
+	marks      mark.Marks
+	marksPath  string
+	jumps      jumplist
+	search     string
+	countdowns []countdown.Event
+	config     *config.Config
+	clock      clock.Clock
+	style      lipgloss.Style
+	months     []month.Month
+	cache      *month.Cache
+	// index is the note index, which is nil until it has loaded in the
+	// background.
+	index     *index.Index
+	indexPath string
+	// unindexed are the days whose notes were edited before the index
+	// loaded, which it may have missed.
+	unindexed   []time.Time
 	preview     preview.Preview
 	previewMode previewMode
 	holidays    holiday.Holidays
+	// holidayErr lists the holiday lists and lines which failed to load,
+	// shown in the status line once the calendar starts.
+	holidayErr  error
 	keywords    keyword.Keywords
+	schedule    workday.Schedule
+	prompt      prompt.Prompt
+	draft       draftHoliday
+	drag        drag
+	click       click
 	height      int
 	width       int
 	initialized bool
+	// hover summarizes the day under the mouse, or is nil when the mouse is
+	// not over a day.
+	hover *status.Hover
+	// countdownGen counts the countdown loads started, so the results of an
+	// older load finishing late are dropped.
+	countdownGen int
 }
 
-// New creates a new calendar model.
-func New(selected time.Time, conf *config.Config) Calendar {
-	now := time.Now()
-	holidays := holiday.Load(conf.HolidayLists)
+// New creates a new calendar model. Today is read from the clock, which may be
+// nil for the system clock.
+func New(selected time.Time, conf *config.Config, clk clock.Clock) Calendar {
+	clk = clock.Or(clk)
+	now := clk.Now().In(conf.Location())
+	holidays, holidayErr := holiday.Load(
+		conf.HolidayLists,
+		conf.HolidayOptions(clk),
+	)
+	cache := month.NewCache()
+	marksPath, err := mark.Path()
+	if err != nil {
+		log.Println(err)
+	}
+	marks, err := mark.Load(marksPath, now.Location())
+	if err != nil {
+		log.Printf("failed loading marks: %v\n", err)
+	}
 	m := Calendar{
 		today:    now,
 		selected: selected,
@@ -75,11 +157,18 @@ func New(selected time.Time, conf *config.Config) Calendar {
 				selected,
 				month.LayoutColumn,
 				holidays,
+				cache,
 				conf,
 			),
 		},
-		holidays: holidays,
-		config:   conf,
+		cache:      cache,
+		holidays:   holidays,
+		holidayErr: holidayErr,
+		schedule:   workday.New(conf.Weekend, holidays),
+		marks:      marks,
+		marksPath:  marksPath,
+		config:     conf,
+		clock:      clk,
 	}
 	m.SetFocus(previewModeShown)
 	return m
@@ -87,7 +176,11 @@ func New(selected time.Time, conf *config.Config) Calendar {
 
 // Init the calendar in Bubble Tea.
 func (c Calendar) Init() tea.Cmd {
-	var cmds []tea.Cmd
+	cmds := []tea.Cmd{
+		status.Error(c.holidayErr),
+		loadIndex(c.config.NoteDir, c.config.Keywords),
+		c.loadCountdowns(),
+	}
 	for _, m := range c.months {
 		cmds = append(cmds, m.Init())
 	}
@@ -97,8 +190,26 @@ func (c Calendar) Init() tea.Cmd {
 // Update the calendar in the Bubble Tea update loop.
 func (c Calendar) Update(msg tea.Msg) (Calendar, tea.Cmd) {
 	var cmds []tea.Cmd
+	// monthFrom is the selected day before moving by a month, which is a jump
+	// if the months move the selection to another month.
+	var monthFrom time.Time
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		c.hover = nil
+		if c.prompt.Active() {
+			var cmd tea.Cmd
+			c.prompt, cmd = c.prompt.Update(msg)
+			return c, cmd
+		}
+		if c.picking {
+			return c, c.pick(msg)
+		}
+		if c.pending != pendingNone {
+			return c.finishPending(msg)
+		}
+		if f, ok := c.yankFormat(msg.String()); ok {
+			return c, c.yank(f)
+		}
 		switch {
 		case c.config.KeySelectLeft.Contains(msg.String()) ||
 			c.config.KeySelectRight.Contains(msg.String()) ||
@@ -108,14 +219,12 @@ func (c Calendar) Update(msg tea.Msg) (Calendar, tea.Cmd) {
 			if c.previewMode != previewModeHidden {
 				c.SetFocus(previewModeShown)
 			}
+		case c.config.KeyMonthUp.Contains(msg.String()) ||
+			c.config.KeyMonthDown.Contains(msg.String()):
+			// The months handle the move itself.
+			monthFrom = c.selected
 		case c.config.KeyEditNote.Contains(msg.String()):
-			path := filepath.Join(os.ExpandEnv(c.config.NoteDir), c.selected.Format("2006-01-02")) + ".md"
-			cmd := tea.ExecProcess(
-				exec.Command(c.config.Editor, path),
-				func(err error) tea.Msg {
-					return editorFinishedMsg{err: err}
-				})
-			return c, cmd
+			return c, c.editNote()
 		case c.config.KeyFocusPreview.Contains(msg.String()):
 			c.ToggleFocus()
 		case c.config.KeyTogglePreview.Contains(msg.String()):
@@ -124,32 +233,175 @@ func (c Calendar) Update(msg tea.Msg) (Calendar, tea.Cmd) {
 			c, cmd = c.resize()
 			cmds = append(cmds, cmd)
 		case c.config.KeyYankDate.Contains(msg.String()):
-			clipboard.WriteAll(c.selected.Format("2006-01-02"))
+			return c, c.yank(config.YankFormat{Format: "2006-01-02"})
+		case c.config.KeyYankPicker.Contains(msg.String()):
+			c.picking = len(c.config.YankFormats) > 0
+			return c, nil
+		case c.config.KeyRange.Contains(msg.String()):
+			c.ToggleRange()
+			return c, nil
+		case c.ranging && msg.Type == tea.KeyEsc:
+			c.ToggleRange()
+			return c, nil
+		case c.ranging && c.config.KeyViewRange.Contains(msg.String()):
+			start, end, _ := c.Range()
+			c.preview = c.preview.SetContent(
+				note.LoadRange(start, end, c.config.NoteDir),
+			)
+			if c.previewMode != previewModeHidden {
+				c.SetFocus(previewModeFocused)
+			}
+			return c, nil
+		case c.ranging && c.config.KeyExportRange.Contains(msg.String()):
+			c.prompt = prompt.New(promptExport, "Export to: ")
+			return c, nil
+		case c.config.KeyNextWorkday.Contains(msg.String()):
+			return c.Select(c.schedule.Next(c.selected))
+		case c.config.KeyLastWorkday.Contains(msg.String()):
+			return c.Select(c.schedule.Last(c.selected))
+		case c.config.KeyMark.Contains(msg.String()):
+			c.pending = pendingMark
+			return c, nil
+		case c.config.KeyJumpMark.Contains(msg.String()):
+			c.pending = pendingJumpMark
+			return c, nil
+		case c.config.KeyJumpBack.Contains(msg.String()):
+			if t, ok := c.jumps.back(c.selected); ok {
+				return c.Select(t)
+			}
+			return c, nil
+		case c.config.KeyJumpForward.Contains(msg.String()):
+			if t, ok := c.jumps.forward(); ok {
+				return c.Select(t)
+			}
+			return c, nil
+		case c.config.KeyNextNote.Contains(msg.String()):
+			return c.find(findNotes, 1)
+		case c.config.KeyLastNote.Contains(msg.String()):
+			return c.find(findNotes, -1)
+		case c.config.KeyNextHoliday.Contains(msg.String()):
+			return c.find(findHolidays, 1)
+		case c.config.KeyLastHoliday.Contains(msg.String()):
+			return c.find(findHolidays, -1)
+		case c.config.KeyNextKeyword.Contains(msg.String()):
+			return c.find(findKeywords, 1)
+		case c.config.KeyLastKeyword.Contains(msg.String()):
+			return c.find(findKeywords, -1)
+		case c.config.KeySearch.Contains(msg.String()):
+			c.prompt = prompt.New(promptSearch, "Search: ")
+			return c, nil
+		case c.config.KeyGoto.Contains(msg.String()):
+			c.prompt = prompt.New(promptGoto, "Go to: ")
+			return c, nil
+		case c.config.KeyAddHoliday.Contains(msg.String()):
+			return c.addHoliday()
+		case c.config.KeyEditHolidays.Contains(msg.String()):
+			return c.editHolidays()
+		}
+	case tea.MouseMsg:
+		if c.Prompting() {
+			return c, nil
+		}
+		return c.mouse(msg)
+	case prompt.SubmitMsg:
+		if isHolidayPrompt(msg.ID) {
+			return c.submitHoliday(msg)
+		}
+		if msg.ID == promptGoto {
+			t, err := parseGoto(msg.Value, c.selected, c.today, c.schedule)
+			if err != nil {
+				c.prompt = c.prompt.Reopen(err)
+				return c, nil
+			}
+			return c.Jump(t)
+		}
+		if msg.ID == promptSearch {
+			c.search = msg.Value
+			return c.find(findKeywords, 1)
+		}
+		if msg.ID == promptExport {
+			start, end, _ := c.Range()
+			err := os.WriteFile(
+				os.ExpandEnv(msg.Value),
+				[]byte(note.LoadRange(start, end, c.config.NoteDir)),
+				0o644,
+			)
+			if err != nil {
+				c.prompt = c.prompt.Reopen(err)
+				return c, nil
+			}
+			return c, status.Message("Exported notes to %v", msg.Value)
 		}
 	case tea.WindowSizeMsg:
 		c.width = msg.Width
 		c.height = msg.Height
 
 		if !c.initialized {
-			note := note.Load(c.selected, c.config.NoteDir)
-			note = c.holidays.Prefix(c.selected, note)
+			note, cmd := c.loadNote(c.selected)
 			c.preview = preview.New(note, c.config)
 			c.initialized = true
+			cmds = append(cmds, cmd)
 		}
 
 		var cmd tea.Cmd
 		c, cmd = c.resize()
 		cmds = append(cmds, cmd)
+	case holidaysEditedMsg:
+		var cmd tea.Cmd
+		c, cmd = c.reloadHolidays()
+		cmds = append(cmds, cmd)
+		if msg.err != nil {
+			cmds = append(cmds, status.Error(
+				fmt.Errorf("failed running %v: %v", c.config.Editor, msg.err),
+			))
+		}
+	case indexMsg:
+		if msg.index != nil {
+			c.index = msg.index
+			c.indexPath = msg.path
+			c.cache.SetIndex(c.index)
+			for _, t := range c.unindexed {
+				cmds = append(cmds, status.Error(c.reindexDay(t)))
+			}
+			c.unindexed = nil
+			cmds = append(cmds, c.reloadCountdowns())
+		}
+		if msg.err != nil {
+			cmds = append(cmds, status.Error(
+				fmt.Errorf("failed loading note index: %v", msg.err),
+			))
+		}
+	case hoverMsg:
+		// Ignore summaries read after the mouse moved onto another day.
+		if c.hover != nil && date.Days(c.hover.Day, msg.hover.Day) == 0 {
+			c.hover = msg.hover
+		}
+	case countdownsMsg:
+		if msg.generation == c.countdownGen {
+			c.countdowns = msg.events
+		}
 	case editorFinishedMsg:
 		// Reload the note when the user exits their editor.
 		var cmd tea.Cmd
 		c, cmd = c.Select(c.selected)
-		cmds = append(cmds, cmd)
+		cmds = append(cmds, cmd, status.Error(c.reindexDay(c.selected)))
+		if c.index == nil {
+			c.unindexed = append(c.unindexed, c.selected)
+		}
+		cmds = append(cmds, c.reloadCountdowns())
+		if msg.err != nil {
+			cmds = append(cmds, status.Error(
+				fmt.Errorf("failed running %v: %v", c.config.Editor, msg.err),
+			))
+		}
 	}
 
 	var cmd tea.Cmd
 	c, cmd = c.propagate(msg)
 	cmds = append(cmds, cmd)
+	if !monthFrom.IsZero() && !date.SameMonth(monthFrom, c.selected) {
+		c.jumps.push(monthFrom)
+	}
 	return c, tea.Batch(cmds...)
 }
 
@@ -200,13 +452,159 @@ func (c Calendar) Select(t time.Time) (Calendar, tea.Cmd) {
 		c, cmd = c.resize()
 	}
 
-	note := note.Load(t, c.config.NoteDir)
-	note = c.holidays.Prefix(t, note)
+	note, noteCmd := c.loadNote(t)
 	c.preview = c.preview.SetContent(note)
 	if c.previewMode != previewModeHidden {
 		c.SetFocus(previewModeShown)
 	}
-	return c, cmd
+	c.applyRange()
+	return c, tea.Batch(cmd, noteCmd)
+}
+
+// loadNote reads the note for a given time prefixed with any holiday. If the
+// note cannot be read the error is displayed in its place and returned as a
+// status message.
+func (c Calendar) loadNote(t time.Time) (string, tea.Cmd) {
+	s, err := note.Read(t, c.config.NoteDir)
+	if err != nil {
+		return err.Error(), status.Error(err)
+	}
+	return c.holidays.Prefix(t, s), nil
+}
+
+// finishPending handles the key press following one which was waiting for
+// another key.
+func (c Calendar) finishPending(msg tea.KeyMsg) (Calendar, tea.Cmd) {
+	p := c.pending
+	c.pending = pendingNone
+	name := msg.String()
+	if !mark.Valid(name) {
+		return c, nil
+	}
+
+	switch p {
+	case pendingMark:
+		c.marks[name] = c.selected
+		if err := c.marks.Save(c.marksPath); err != nil {
+			return c, status.Error(fmt.Errorf("failed saving marks: %v", err))
+		}
+		return c, status.Message("Marked %v as %v", c.selected.Format("2006-01-02"), name)
+	case pendingJumpMark:
+		if t, ok := c.marks[name]; ok {
+			return c.Jump(t)
+		}
+		return c, status.Message("Mark %v is not set", name)
+	}
+	return c, nil
+}
+
+// editNote opens the selected day's note in the editor.
+func (c Calendar) editNote() tea.Cmd {
+	path := filepath.Join(os.ExpandEnv(c.config.NoteDir), c.selected.Format("2006-01-02")) + ".md"
+	return tea.ExecProcess(
+		exec.Command(c.config.Editor, path),
+		func(err error) tea.Msg {
+			return editorFinishedMsg{err: err}
+		})
+}
+
+// Jump selects a different date and records the move in the jumplist.
+func (c Calendar) Jump(t time.Time) (Calendar, tea.Cmd) {
+	c.jumps.push(c.selected)
+	return c.Select(t)
+}
+
+// yankFormat returns the yank format bound to a key.
+func (c Calendar) yankFormat(key string) (config.YankFormat, bool) {
+	for _, f := range c.config.YankFormats {
+		if f.Keys.Contains(key) {
+			return f, true
+		}
+	}
+	return config.YankFormat{}, false
+}
+
+// pick a yank format from the picker by its number. Any other key closes the
+// picker.
+func (c *Calendar) pick(msg tea.KeyMsg) tea.Cmd {
+	c.picking = false
+	n, err := strconv.Atoi(msg.String())
+	if err != nil || n < 1 || n > len(c.config.YankFormats) {
+		return nil
+	}
+	return c.yank(c.config.YankFormats[n-1])
+}
+
+// yank copies the selected day, or range, into the clipboard using a given
+// format. The returned tea.Cmd reports what was copied or any error.
+func (c Calendar) yank(f config.YankFormat) tea.Cmd {
+	l := locale.Get(c.config.Locale)
+	var s string
+	if start, end, ok := c.Range(); ok {
+		s = yank.FormatRange(start, end, f, l, c.config.NoteDir)
+	} else {
+		s = yank.Format(c.selected, f, l, c.config.NoteDir)
+	}
+	osc, err := yank.Copy(s, c.config.Clipboard)
+	if err != nil {
+		return status.Error(fmt.Errorf("failed copying: %v", err))
+	}
+	copied := status.Message("Copied %v", s)
+	if osc == nil {
+		return copied
+	}
+	// The terminal is handed the escape sequence between renders.
+	return tea.Exec(osc, func(err error) tea.Msg {
+		if err != nil {
+			return status.Error(fmt.Errorf("failed copying: %v", err))()
+		}
+		return copied()
+	})
+}
+
+// ToggleRange starts or stops selecting a range of days. The range stretches
+// from the day selected when it was started to the currently selected day.
+func (c *Calendar) ToggleRange() {
+	c.ranging = !c.ranging
+	c.anchor = c.selected
+	c.applyRange()
+}
+
+// Range returns the first and last days of the selected range and reports if
+// a range is being selected.
+func (c Calendar) Range() (time.Time, time.Time, bool) {
+	if !c.ranging {
+		return time.Time{}, time.Time{}, false
+	}
+	start := time.Date(
+		c.anchor.Year(), c.anchor.Month(), c.anchor.Day(),
+		0, 0, 0, 0,
+		c.anchor.Location(),
+	)
+	end := time.Date(
+		c.selected.Year(), c.selected.Month(), c.selected.Day(),
+		0, 0, 0, 0,
+		c.selected.Location(),
+	)
+	if end.Before(start) {
+		start, end = end, start
+	}
+	return start, end, true
+}
+
+// applyRange updates the range displayed by each month.
+func (c *Calendar) applyRange() {
+	start, end, _ := c.Range()
+	for id := range c.months {
+		c.months[id].SetRange(start, end)
+	}
+}
+
+// Prompting reports if the calendar is currently reading text input, a choice
+// from the yank picker, or the name of a mark, in which case key presses should
+// not be handled elsewhere.
+func (c Calendar) Prompting() bool {
+	return c.prompt.Active() || c.picking || c.pending != pendingNone
 }
 
 // SetFocus sets the focus to the months or the preview window.
@@ -248,7 +646,14 @@ func (c *Calendar) TogglePreview() {
 	}
 }
 
-// SetToday sets the today value to a new time.
+// ClearHover removes the summary of the day under the mouse, such as when the
+// calendar is hidden.
+func (c *Calendar) ClearHover() {
+	c.hover = nil
+}
+
+// SetToday sets the today value to a new time. The countdowns are from the
+// old today until reloadCountdowns is run.
 func (c *Calendar) SetToday(t time.Time) {
 	c.today = t
 	for id := range c.months {
@@ -256,16 +661,77 @@ func (c *Calendar) SetToday(t time.Time) {
 	}
 }
 
+// Today returns the today value.
+func (c Calendar) Today() time.Time {
+	return c.today
+}
+
+// Rollover sets the today value to a new time after the day has changed. If
+// configured to follow today and the old today was selected, the new today is
+// selected instead.
+func (c Calendar) Rollover(t time.Time) (Calendar, tea.Cmd) {
+	follow := c.config.FollowToday && date.Days(c.today, c.selected) == 0
+	c.SetToday(t)
+	cmd := c.reloadCountdowns()
+	if follow {
+		var selectCmd tea.Cmd
+		c, selectCmd = c.Select(t)
+		return c, tea.Batch(cmd, selectCmd)
+	}
+	return c, cmd
+}
+
+// countdownsMsg is a tea.Msg returned when the upcoming countdown events have
+// been found by a countdown load.
+type countdownsMsg struct {
+	generation int
+	events     []countdown.Event
+}
+
+// loadCountdowns returns a tea.Cmd which finds the upcoming countdown events
+// from today. This happens in the background as it may read every note, until
+// the note index has loaded.
+func (c Calendar) loadCountdowns() tea.Cmd {
+	generation := c.countdownGen
+	today := c.today
+	holidays := c.holidays
+	keywords := c.config.Keywords
+	schedule := c.schedule
+	dir := c.config.NoteDir
+	ix := c.index
+	return func() tea.Msg {
+		return countdownsMsg{
+			generation: generation,
+			events: countdown.Upcoming(
+				today,
+				holidays,
+				keywords,
+				schedule,
+				dir,
+				ix,
+			),
+		}
+	}
+}
+
+// reloadCountdowns is like loadCountdowns, but replaces any load already
+// started, whose results are dropped.
+func (c *Calendar) reloadCountdowns() tea.Cmd {
+	c.countdownGen++
+	return c.loadCountdowns()
+}
+
 // renderMonths displays a grid of months.
 func (c Calendar) renderMonths() string {
 	var rows []string
 	switch len(c.months) {
 	case 12:
 		banner := c.selected.Format("2006")
-		if h, ok := c.holidays.Match(c.selected); ok {
-			banner = h.Message + " " + banner
+		messages := c.holidays.MatchAll(c.selected).Messages()
+		if len(messages) > 0 {
+			banner = strings.Join(messages, ", ") + " " + banner
 		}
-		rows = append(rows, banner)
+		rows = append(rows, zone.Mark(zoneYear, banner))
 		for i := 0; i < 3; i++ {
 			var column []string
 			column = append(column, c.months[0+i*4].View()+strings.Repeat(" ",
@@ -297,6 +763,49 @@ func (c Calendar) renderMonths() string {
 	return lipgloss.JoinVertical(lipgloss.Center, rows...)
 }
 
+// renderPicker displays the numbered list of yank formats.
+func (c Calendar) renderPicker() string {
+	var b strings.Builder
+	b.WriteString("Copy as:")
+	for i, f := range c.config.YankFormats {
+		b.WriteString(fmt.Sprintf("  %v %v", i+1, f.Name))
+	}
+	return b.String()
+}
+
+// Details describes the selected day, or range, for the status line.
+func (c Calendar) Details() status.Details {
+	d := status.Details{
+		Selected: c.selected,
+		Today:    c.today,
+		Range:    c.rangeSummary(),
+		Hover:    c.hover,
+	}
+	if len(c.countdowns) > 0 {
+		d.Countdown = c.countdowns[0].Describe(locale.Get(c.config.Locale))
+	}
+	d.Holiday = strings.Join(c.holidays.MatchAll(c.selected).Messages(), ", ")
+	return d
+}
+
+// rangeSummary describes the selected range or returns a blank string.
+func (c Calendar) rangeSummary() string {
+	start, end, ok := c.Range()
+	if !ok {
+		return ""
+	}
+	l := locale.Get(c.config.Locale)
+	days := date.Days(start, end) + 1
+	workdays := c.schedule.Count(start.AddDate(0, 0, -1), end)
+	return fmt.Sprintf(
+		"%v..%v  %v, %v",
+		start.Format("2006-01-02"),
+		end.Format("2006-01-02"),
+		l.Count(l.DayCount, days),
+		l.Count(l.BusinessDays, workdays),
+	)
+}
+
 // renderPreview displays the preview window or returns a blank string.
 func (c Calendar) renderPreview() string {
 	if c.previewMode != previewModeHidden {
@@ -311,9 +820,15 @@ func (c Calendar) View() string {
 		return ""
 	}
 
-	return c.style.Render(lipgloss.JoinHorizontal(
+	view := lipgloss.JoinHorizontal(
 		lipgloss.Center,
 		c.renderMonths(),
 		c.renderPreview(),
-	))
+	)
+	if c.prompt.Active() {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, c.prompt.View())
+	} else if c.picking {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, c.renderPicker())
+	}
+	return c.style.Render(view)
 }
@@ -0,0 +1,193 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package calendar
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"git.sr.ht/~kota/calendar/clock"
+	"git.sr.ht/~kota/calendar/config"
+	"git.sr.ht/~kota/calendar/date"
+	"git.sr.ht/~kota/calendar/keyword"
+	"git.sr.ht/~kota/calendar/note"
+	"git.sr.ht/~kota/calendar/status"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/muesli/termenv"
+)
+
+func TestLoadCountdowns(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	conf := config.Default()
+	conf.NoteDir = t.TempDir()
+	conf.HolidayLists = nil
+	conf.Keywords = keyword.Keywords{{Keyword: "DEADLINE", Countdown: true}}
+	day := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.Local)
+	release := day.AddDate(0, 0, 3)
+	path := note.Path(release, conf.NoteDir)
+	if err := os.WriteFile(path, []byte("DEADLINE: release\n"), 0o644); err != nil {
+		t.Fatalf("failed writing note: %v", err)
+	}
+
+	// Creating the calendar reads no notes, the countdowns load in the
+	// background.
+	c := New(day, conf, clock.Frozen(day))
+	if len(c.countdowns) != 0 {
+		t.Fatalf("want no countdowns before loading, got: %v", c.countdowns)
+	}
+	stale := c.loadCountdowns()()
+	c, _ = c.Update(stale)
+	if len(c.countdowns) != 1 || c.countdowns[0].Days != 3 {
+		t.Fatalf("want the release in 3 days, got: %v", c.countdowns)
+	}
+
+	// The results of a load, such as the one started by New, are dropped if
+	// they arrive after a newer load has started.
+	c, cmd := c.Rollover(day.AddDate(0, 0, 1))
+	if cmd == nil {
+		t.Fatalf("expected the day changing to load the countdowns")
+	}
+	c, _ = c.Update(c.loadCountdowns()())
+	c, _ = c.Update(stale)
+	if len(c.countdowns) != 1 || c.countdowns[0].Days != 2 {
+		t.Fatalf("want the release in 2 days, got: %v", c.countdowns)
+	}
+}
+
+func TestRange(t *testing.T) {
+	lipgloss.SetColorProfile(termenv.ANSI)
+	zone.NewGlobal()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	conf := config.Default()
+	conf.NoteDir = t.TempDir()
+	conf.HolidayLists = nil
+	day := func(d int) time.Time {
+		return time.Date(2025, time.March, d, 0, 0, 0, 0, time.UTC)
+	}
+	selected := day(14).Add(9*time.Hour + 30*time.Minute)
+	c := New(selected, conf, clock.Frozen(selected))
+	c, _ = c.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	if _, _, ok := c.Range(); ok {
+		t.Fatalf("expected no range before one is started")
+	}
+
+	// Moving back from where the range started still gives the days in
+	// order, at the start of each day.
+	c, _ = c.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	c, _ = c.Select(day(10))
+	first, last, ok := c.Range()
+	if !ok || !first.Equal(day(10)) || !last.Equal(day(14)) {
+		t.Fatalf("want: %v..%v, got: %v..%v %v", day(10), day(14), first, last, ok)
+	}
+	// The months show the range in the RangeStyle.
+	ranged := conf.RangeStyle.Export(lipgloss.NewStyle()).Render("12")
+	if !strings.Contains(c.View(), ranged) {
+		t.Fatalf("expected the months to show the range, got: %q", c.View())
+	}
+
+	// Moving past where the range started flips it around.
+	c, _ = c.Select(day(20))
+	first, last, _ = c.Range()
+	if !first.Equal(day(14)) || !last.Equal(day(20)) {
+		t.Fatalf("want: %v..%v, got: %v..%v", day(14), day(20), first, last)
+	}
+
+	// Escape stops selecting the range.
+	c, _ = c.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if _, _, ok := c.Range(); ok {
+		t.Fatalf("expected escape to clear the range")
+	}
+	if strings.Contains(c.View(), ranged) {
+		t.Fatalf("expected the months to stop showing the range")
+	}
+
+	// Toggling again starts a new range from the selected day.
+	c.ToggleRange()
+	first, last, ok = c.Range()
+	if !ok || !first.Equal(day(20)) || !last.Equal(day(20)) {
+		t.Fatalf("want a range of only %v, got: %v..%v %v", day(20), first, last, ok)
+	}
+	c.ToggleRange()
+	if _, _, ok := c.Range(); ok {
+		t.Fatalf("expected toggling twice to clear the range")
+	}
+}
+
+func TestMonthMoveJump(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	conf := config.Default()
+	conf.NoteDir = t.TempDir()
+	conf.HolidayLists = nil
+	day := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC)
+	c := New(day, conf, clock.Frozen(day))
+	c, _ = c.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	ctrlD := tea.KeyMsg{Type: tea.KeyCtrlD}
+	tab := tea.KeyMsg{Type: tea.KeyTab}
+
+	// With the preview focused the selection does not move, so nothing is
+	// pushed onto the jumplist.
+	c, _ = c.Update(tab)
+	c, _ = c.Update(ctrlD)
+	if !c.selected.Equal(day) {
+		t.Fatalf("want the selection kept at %v, got: %v", day, c.selected)
+	}
+	if got, ok := c.jumps.back(c.selected); ok {
+		t.Fatalf("want an empty jumplist, got: %v", got)
+	}
+
+	// Moving to another month is a jump.
+	c, _ = c.Update(tab)
+	c, _ = c.Update(ctrlD)
+	if date.SameMonth(c.selected, day) {
+		t.Fatalf("want the selection moved from %v, got: %v", day, c.selected)
+	}
+	if got, ok := c.jumps.back(c.selected); !ok || !got.Equal(day) {
+		t.Fatalf("want a jump back to %v, got: %v %v", day, got, ok)
+	}
+}
+
+func TestHover(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	conf := config.Default()
+	conf.NoteDir = t.TempDir()
+	conf.HolidayLists = nil
+	conf.Keywords = keyword.Keywords{{Keyword: "dentist"}}
+	day := time.Date(2025, time.March, 20, 0, 0, 0, 0, time.Local)
+	text := "\ndentist at 9\nbring the forms\nthird line\n"
+	path := note.Path(day, conf.NoteDir)
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		t.Fatalf("failed writing note: %v", err)
+	}
+
+	msg := hoverMsg{
+		hover: summarize(status.Hover{Day: day}, conf.NoteDir, conf.Keywords),
+	}
+	got := msg.hover
+	if len(got.Keywords) != 1 || got.Keywords[0] != "dentist" {
+		t.Fatalf("want the dentist keyword, got: %v", got.Keywords)
+	}
+	if strings.Join(got.Lines, "|") != "dentist at 9|bring the forms" {
+		t.Fatalf("want the first two lines, got: %q", got.Lines)
+	}
+
+	// A summary read after the mouse moved onto another day is dropped.
+	c := New(day, conf, clock.Frozen(day))
+	c.hover = &status.Hover{Day: day.AddDate(0, 0, 1)}
+	c, _ = c.Update(msg)
+	if len(c.hover.Lines) != 0 {
+		t.Fatalf("want the summary of another day dropped, got: %v", c.hover)
+	}
+	c.hover = &status.Hover{Day: day}
+	c, _ = c.Update(msg)
+	if len(c.hover.Lines) != 2 {
+		t.Fatalf("want the summary of the hovered day, got: %v", c.hover)
+	}
+	c.ClearHover()
+	if c, _ = c.Update(msg); c.hover != nil {
+		t.Fatalf("want no summary after clearing, got: %v", c.hover)
+	}
+}
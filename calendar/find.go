@@ -0,0 +1,191 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package calendar
+
+import (
+	"os"
+	"sort"
+	"time"
+
+	"git.sr.ht/~kota/calendar/keyword"
+	"git.sr.ht/~kota/calendar/note"
+	"git.sr.ht/~kota/calendar/status"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// findKind describes what sort of day to search for.
+type findKind uint8
+
+const (
+	// findNotes searches for days with a note.
+	findNotes findKind = iota
+	// findHolidays searches for days matching a holiday.
+	findHolidays
+	// findKeywords searches for days with a note containing the searched text
+	// or, if nothing has been searched for, any of the configured keywords.
+	findKeywords
+)
+
+// find jumps to the nearest day after the selected day which matches the
+// given kind. If step is negative the nearest day before is used instead. A
+// status message is shown if there is no such day.
+func (c Calendar) find(kind findKind, step int) (Calendar, tea.Cmd) {
+	if kind != findHolidays {
+		if err := c.updateIndex(); err != nil {
+			return c, status.Error(err)
+		}
+	}
+
+	var t time.Time
+	var ok bool
+	switch kind {
+	case findNotes:
+		t, ok = c.nearestNote(step, nil)
+	case findHolidays:
+		t, ok = c.holidays.Find(c.selected, step)
+	case findKeywords:
+		ks := c.config.Keywords
+		if c.search != "" {
+			ks = keyword.Keywords{{Keyword: c.search}}
+		}
+		if len(ks) == 0 {
+			return c, status.Message("No keywords are configured")
+		}
+		t, ok = c.nearestNote(step, c.keywordMatcher(ks))
+	}
+
+	if !ok {
+		return c, status.Message("No %v %v", later(step), kind)
+	}
+	return c.Jump(t)
+}
+
+// String describes the kind of day being searched for in messages.
+func (k findKind) String() string {
+	switch k {
+	case findHolidays:
+		return "holidays"
+	case findKeywords:
+		return "matches"
+	default:
+		return "notes"
+	}
+}
+
+// later describes the direction of a search step in messages.
+func later(step int) string {
+	if step < 0 {
+		return "earlier"
+	}
+	return "later"
+}
+
+// nearestNote is like findNote from the selected day, but uses the note index
+// instead of reading the note directory once the index is loaded.
+func (c Calendar) nearestNote(
+	step int,
+	match func(time.Time) bool,
+) (time.Time, bool) {
+	if c.index == nil {
+		return findNote(c.selected, step, c.config.NoteDir, match)
+	}
+	return nearest(
+		c.selected,
+		step,
+		c.index.Dates(c.selected.Location()),
+		match,
+	)
+}
+
+// keywordMatcher is like matchKeywords, but uses the note index once it is
+// loaded. The configured keywords are looked up in the index without reading
+// any notes. Searched text only reads the notes the index cannot rule out.
+func (c Calendar) keywordMatcher(ks keyword.Keywords) func(time.Time) bool {
+	read := matchKeywords(ks, c.config.NoteDir)
+	if c.index == nil {
+		return read
+	}
+	if c.search == "" {
+		return func(t time.Time) bool {
+			_, _, ok := c.index.Keyword(t)
+			return ok
+		}
+	}
+	days, ok := c.index.Candidates(c.search)
+	if !ok {
+		return read
+	}
+	return func(t time.Time) bool {
+		return days[t.Format("2006-01-02")] && read(t)
+	}
+}
+
+// findNote returns the nearest day after time t which has a note and matches
+// a predicate. If step is negative the nearest day before time t is returned
+// instead. A nil predicate matches every note.
+//
+// The note directory is read once rather than checking each day, so this is
+// quick even when the nearest note is years away.
+func findNote(
+	t time.Time,
+	step int,
+	dir string,
+	match func(time.Time) bool,
+) (time.Time, bool) {
+	dates, err := note.Dates(dir, t.Location())
+	if err != nil {
+		return t, false
+	}
+	return nearest(t, step, dates, match)
+}
+
+// nearest returns the nearest of the ascending dates after time t which
+// matches a predicate, or before time t if step is negative.
+func nearest(
+	t time.Time,
+	step int,
+	dates []time.Time,
+	match func(time.Time) bool,
+) (time.Time, bool) {
+	if len(dates) == 0 {
+		return t, false
+	}
+
+	day := t.Format("2006-01-02")
+	if step < 0 {
+		i := sort.Search(len(dates), func(i int) bool {
+			return dates[i].Format("2006-01-02") >= day
+		})
+		for i--; i >= 0; i-- {
+			if match == nil || match(dates[i]) {
+				return dates[i], true
+			}
+		}
+		return t, false
+	}
+
+	i := sort.Search(len(dates), func(i int) bool {
+		return dates[i].Format("2006-01-02") > day
+	})
+	for ; i < len(dates); i++ {
+		if match == nil || match(dates[i]) {
+			return dates[i], true
+		}
+	}
+	return t, false
+}
+
+// matchKeywords returns a predicate which reports if the note for a given day
+// contains any of the keywords.
+func matchKeywords(ks keyword.Keywords, dir string) func(time.Time) bool {
+	return func(t time.Time) bool {
+		f, err := os.Open(note.Path(t, dir))
+		if err != nil {
+			return false
+		}
+		defer f.Close()
+
+		_, ok := ks.Match(f)
+		return ok
+	}
+}
@@ -0,0 +1,138 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package calendar
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"git.sr.ht/~kota/calendar/config"
+	"git.sr.ht/~kota/calendar/index"
+	"git.sr.ht/~kota/calendar/keyword"
+)
+
+func TestFindNote(t *testing.T) {
+	dir := t.TempDir()
+	notes := map[string]string{
+		"2019-03-01.md": "APPT dentist.",
+		"2023-01-06.md": "Nothing much.",
+		"2023-01-09.md": "APPT doctor.",
+		"2031-07-20.md": "Far away.",
+	}
+	for name, content := range notes {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644)
+		if err != nil {
+			t.Fatalf("failed writing %v: %v", name, err)
+		}
+	}
+
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	appt := matchKeywords(keyword.Keywords{{Keyword: "APPT"}}, dir)
+
+	type test struct {
+		description string
+		from        time.Time
+		step        int
+		match       func(time.Time) bool
+		want        time.Time
+		wantOK      bool
+	}
+
+	tests := []test{
+		{
+			description: "next note",
+			from:        day(2023, time.January, 6),
+			step:        1,
+			want:        day(2023, time.January, 9),
+			wantOK:      true,
+		},
+		{
+			description: "last note across years",
+			from:        day(2023, time.January, 6),
+			step:        -1,
+			want:        day(2019, time.March, 1),
+			wantOK:      true,
+		},
+		{
+			description: "next note far away",
+			from:        day(2023, time.January, 9),
+			step:        1,
+			want:        day(2031, time.July, 20),
+			wantOK:      true,
+		},
+		{
+			description: "no notes after",
+			from:        day(2031, time.July, 20),
+			step:        1,
+			want:        day(2031, time.July, 20),
+		},
+		{
+			description: "last keyword",
+			from:        day(2023, time.January, 8),
+			step:        -1,
+			match:       appt,
+			want:        day(2019, time.March, 1),
+			wantOK:      true,
+		},
+		{
+			description: "next keyword",
+			from:        day(2020, time.January, 1),
+			step:        1,
+			match:       appt,
+			want:        day(2023, time.January, 9),
+			wantOK:      true,
+		},
+	}
+
+	for _, tc := range tests {
+		got, ok := findNote(tc.from, tc.step, dir, tc.match)
+		if ok != tc.wantOK || !got.Equal(tc.want) {
+			t.Fatalf(
+				"%v: want: %v %v, got: %v %v",
+				tc.description,
+				tc.want,
+				tc.wantOK,
+				got,
+				ok,
+			)
+		}
+	}
+}
+
+func TestNearestNoteIndexed(t *testing.T) {
+	conf := config.Default()
+	conf.NoteDir = t.TempDir()
+	ix := index.New()
+	if _, err := ix.Update(conf.NoteDir, conf.Keywords); err != nil {
+		t.Fatalf("failed updating index: %v", err)
+	}
+	c := Calendar{
+		selected:  time.Date(2023, time.January, 6, 0, 0, 0, 0, time.UTC),
+		config:    conf,
+		index:     ix,
+		indexPath: filepath.Join(t.TempDir(), "index"),
+	}
+
+	// A note written after the index loaded is found once it is updated.
+	path := filepath.Join(conf.NoteDir, "2023-01-09.md")
+	if err := os.WriteFile(path, []byte("APPT doctor."), 0o644); err != nil {
+		t.Fatalf("failed writing note: %v", err)
+	}
+	if _, ok := c.nearestNote(1, nil); ok {
+		t.Fatalf("expected the stale index not to have the note")
+	}
+	if err := c.updateIndex(); err != nil {
+		t.Fatalf("failed updating index: %v", err)
+	}
+	want := time.Date(2023, time.January, 9, 0, 0, 0, 0, time.UTC)
+	if got, ok := c.nearestNote(1, nil); !ok || !got.Equal(want) {
+		t.Fatalf("want: %v, got: %v %v", want, got, ok)
+	}
+	if _, err := os.Stat(c.indexPath); err != nil {
+		t.Fatalf("expected the updated index to be saved: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package calendar
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"git.sr.ht/~kota/calendar/workday"
+)
+
+// relativePattern matches relative go-to offsets such as "+3", "-2w", or
+// "+10bd".
+var relativePattern = regexp.MustCompile(`^([+-])(\d+)(d|w|m|y|bd)?$`)
+
+// parseGoto reads a go-to expression and returns the time it refers to.
+//
+// Absolute dates are given as 2006-01-02 or 01-02 (in the selected year).
+// Relative offsets are a sign, a number, and an optional unit: d (days, the
+// default), w (weeks), m (months), y (years), or bd (business days). The word
+// "today" is also accepted.
+func parseGoto(
+	s string,
+	selected, today time.Time,
+	schedule workday.Schedule,
+) (time.Time, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return selected, fmt.Errorf("empty date")
+	}
+	if s == "today" {
+		return today, nil
+	}
+
+	if m := relativePattern.FindStringSubmatch(s); m != nil {
+		n, err := strconv.Atoi(m[2])
+		if err != nil {
+			return selected, fmt.Errorf("invalid number %v", m[2])
+		}
+		if m[1] == "-" {
+			n = -n
+		}
+		switch m[3] {
+		case "w":
+			return selected.AddDate(0, 0, n*7), nil
+		case "m":
+			return selected.AddDate(0, n, 0), nil
+		case "y":
+			return selected.AddDate(n, 0, 0), nil
+		case "bd":
+			return schedule.Add(selected, n), nil
+		default:
+			return selected.AddDate(0, 0, n), nil
+		}
+	}
+
+	t, err := time.ParseInLocation("2006-01-02", s, selected.Location())
+	if err == nil {
+		return t, nil
+	}
+	t, err = time.ParseInLocation("01-02", s, selected.Location())
+	if err == nil {
+		return time.Date(
+			selected.Year(),
+			t.Month(),
+			t.Day(),
+			0, 0, 0, 0,
+			selected.Location(),
+		), nil
+	}
+	return selected, fmt.Errorf("invalid date %v", s)
+}
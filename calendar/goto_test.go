@@ -0,0 +1,78 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"git.sr.ht/~kota/calendar/config"
+	"git.sr.ht/~kota/calendar/workday"
+)
+
+func TestParseGoto(t *testing.T) {
+	selected := time.Date(2023, time.January, 6, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	schedule := workday.New(config.Weekdays{"Saturday", "Sunday"}, nil)
+
+	type test struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}
+
+	tests := []test{
+		{
+			input: "today",
+			want:  today,
+		},
+		{
+			input: "2024-02-29",
+			want:  time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input: "12-25",
+			want:  time.Date(2023, time.December, 25, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input: "+3",
+			want:  time.Date(2023, time.January, 9, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input: "-2w",
+			want:  time.Date(2022, time.December, 23, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input: "+1m",
+			want:  time.Date(2023, time.February, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input: "+10bd",
+			want:  time.Date(2023, time.January, 20, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input: " -1BD ",
+			want:  time.Date(2023, time.January, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			input:   "next tuesday",
+			want:    selected,
+			wantErr: true,
+		},
+		{
+			input:   "",
+			want:    selected,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := parseGoto(tc.input, selected, today, schedule)
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("input: %q, unexpected error: %v", tc.input, err)
+		}
+		if !got.Equal(tc.want) {
+			t.Fatalf("input: %q, want: %v, got: %v", tc.input, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package calendar
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"git.sr.ht/~kota/calendar/holiday"
+	"git.sr.ht/~kota/calendar/prompt"
+	"git.sr.ht/~kota/calendar/status"
+	"git.sr.ht/~kota/calendar/workday"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// promptHolidayMessage is the id of the prompt asking for the message of
+	// a new holiday.
+	promptHolidayMessage = "holiday-message"
+	// promptHolidayColor is the id of the prompt asking for the color of a
+	// new holiday.
+	promptHolidayColor = "holiday-color"
+	// promptHolidayRecurrence is the id of the prompt asking how often a new
+	// holiday repeats.
+	promptHolidayRecurrence = "holiday-recurrence"
+	// promptHolidayList is the id of the prompt asking which list to add a new
+	// holiday to.
+	promptHolidayList = "holiday-list"
+	// promptEditHolidays is the id of the prompt asking which list to open in
+	// the editor.
+	promptEditHolidays = "edit-holidays"
+)
+
+// defaultHolidayColor is used for a new holiday if no color is given.
+const defaultHolidayColor = "3"
+
+// draftHoliday is a holiday being added which is filled in by a series of
+// prompts.
+type draftHoliday struct {
+	message    string
+	color      string
+	recurrence holiday.Recurrence
+}
+
+// holidaysEditedMsg is a tea.Msg returned when the editor opened on a holiday
+// list returns.
+type holidaysEditedMsg struct{ err error }
+
+// holidayFiles returns the configured holiday lists which are files, rather
+// than builtin lists, and so can be written to.
+func (c Calendar) holidayFiles() []string {
+	var files []string
+	for _, l := range c.config.HolidayLists {
+		if !strings.HasPrefix(l, holiday.BuiltinPrefix) {
+			files = append(files, l)
+		}
+	}
+	return files
+}
+
+// listLabel is the label of a prompt choosing one of the holiday files by
+// number, such as "List (1 public, 2 birthdays): ".
+func listLabel(files []string) string {
+	names := make([]string, len(files))
+	for i, f := range files {
+		names[i] = fmt.Sprintf("%v %v", i+1, filepath.Base(f))
+	}
+	return fmt.Sprintf("List (%v): ", strings.Join(names, ", "))
+}
+
+// chooseList returns the holiday file chosen by its number. An empty choice is
+// the first file.
+func chooseList(files []string, s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return files[0], nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 || n > len(files) {
+		return "", fmt.Errorf("choose a list from 1 to %v", len(files))
+	}
+	return files[n-1], nil
+}
+
+// addHoliday starts adding a holiday on the selected day by prompting for its
+// message.
+func (c Calendar) addHoliday() (Calendar, tea.Cmd) {
+	if len(c.holidayFiles()) == 0 {
+		return c, status.Message("No holiday list files are configured")
+	}
+	c.draft = draftHoliday{}
+	c.prompt = prompt.New(promptHolidayMessage, "Holiday message: ")
+	return c, nil
+}
+
+// editHolidays opens a holiday list in the editor, prompting for which list if
+// there are several.
+func (c Calendar) editHolidays() (Calendar, tea.Cmd) {
+	files := c.holidayFiles()
+	switch len(files) {
+	case 0:
+		return c, status.Message("No holiday list files are configured")
+	case 1:
+		return c, c.openHolidays(files[0])
+	}
+	c.prompt = prompt.New(promptEditHolidays, listLabel(files))
+	return c, nil
+}
+
+// openHolidays opens a holiday list in the editor.
+func (c Calendar) openHolidays(path string) tea.Cmd {
+	return tea.ExecProcess(
+		exec.Command(c.config.Editor, os.ExpandEnv(path)),
+		func(err error) tea.Msg {
+			return holidaysEditedMsg{err: err}
+		})
+}
+
+// isHolidayPrompt reports if a prompt id belongs to adding or editing
+// holidays.
+func isHolidayPrompt(id string) bool {
+	switch id {
+	case promptHolidayMessage,
+		promptHolidayColor,
+		promptHolidayRecurrence,
+		promptHolidayList,
+		promptEditHolidays:
+		return true
+	}
+	return false
+}
+
+// submitHoliday handles an answer to one of the holiday prompts, moving on to
+// the next prompt or writing the holiday once every answer is given.
+func (c Calendar) submitHoliday(msg prompt.SubmitMsg) (Calendar, tea.Cmd) {
+	files := c.holidayFiles()
+	switch msg.ID {
+	case promptHolidayMessage:
+		c.draft.message = strings.TrimSpace(msg.Value)
+		c.prompt = prompt.New(
+			promptHolidayColor,
+			fmt.Sprintf("Color (%v): ", defaultHolidayColor),
+		)
+	case promptHolidayColor:
+		color := strings.TrimSpace(msg.Value)
+		if strings.ContainsAny(color, " \t") {
+			c.prompt = c.prompt.Reopen(fmt.Errorf("colors cannot contain spaces"))
+			return c, nil
+		}
+		if color == "" {
+			color = defaultHolidayColor
+		}
+		c.draft.color = color
+		c.prompt = prompt.New(
+			promptHolidayRecurrence,
+			"Repeat (once, yearly, monthly): ",
+		)
+	case promptHolidayRecurrence:
+		r, err := holiday.ParseRecurrence(msg.Value)
+		if err != nil {
+			c.prompt = c.prompt.Reopen(err)
+			return c, nil
+		}
+		c.draft.recurrence = r
+		if len(files) == 1 {
+			return c.writeHoliday(files[0])
+		}
+		c.prompt = prompt.New(promptHolidayList, listLabel(files))
+	case promptHolidayList, promptEditHolidays:
+		path, err := chooseList(files, msg.Value)
+		if err != nil {
+			c.prompt = c.prompt.Reopen(err)
+			return c, nil
+		}
+		if msg.ID == promptEditHolidays {
+			return c, c.openHolidays(path)
+		}
+		return c.writeHoliday(path)
+	}
+	return c, nil
+}
+
+// writeHoliday appends the drafted holiday to a list and reloads the holidays.
+func (c Calendar) writeHoliday(path string) (Calendar, tea.Cmd) {
+	line := holiday.Line(
+		c.selected,
+		c.draft.recurrence,
+		c.draft.color,
+		c.draft.message,
+	)
+	if err := holiday.Append(path, line); err != nil {
+		return c, status.Error(err)
+	}
+	c, cmd := c.reloadHolidays()
+	return c, tea.Batch(cmd, status.Message("Added holiday to %v", path))
+}
+
+// reloadHolidays reads the holiday lists again and passes them to the months,
+// updating everything which depends on them.
+func (c Calendar) reloadHolidays() (Calendar, tea.Cmd) {
+	holidays, err := holiday.Load(
+		c.config.HolidayLists,
+		c.config.HolidayOptions(c.clock),
+	)
+	c.holidays = holidays
+	c.holidayErr = err
+	c.schedule = workday.New(c.config.Weekend, holidays)
+
+	cmds := []tea.Cmd{status.Error(err), c.reloadCountdowns()}
+	c.cache.Clear()
+	for i := range c.months {
+		c.months[i].SetHolidays(holidays)
+		cmds = append(cmds, c.months[i].Init())
+	}
+	var cmd tea.Cmd
+	c, cmd = c.Select(c.selected)
+	cmds = append(cmds, cmd)
+	return c, tea.Batch(cmds...)
+}
@@ -0,0 +1,96 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package calendar
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"git.sr.ht/~kota/calendar/clock"
+	"git.sr.ht/~kota/calendar/config"
+	"git.sr.ht/~kota/calendar/prompt"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestAddHoliday(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dir)
+	public := filepath.Join(dir, "public")
+	birthdays := filepath.Join(dir, "birthdays")
+	if err := os.WriteFile(public, []byte("01-01 1 New year\n"), 0o644); err != nil {
+		t.Fatalf("failed writing list: %v", err)
+	}
+
+	conf := config.Default()
+	conf.NoteDir = dir
+	conf.HolidayLists = []string{"builtin:seasons", public, birthdays}
+	day := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.Local)
+	c := New(day, conf, clock.Frozen(day))
+
+	c, _ = c.addHoliday()
+	answers := []prompt.SubmitMsg{
+		{ID: promptHolidayMessage, Value: "Pi day"},
+		{ID: promptHolidayColor, Value: ""},
+		{ID: promptHolidayRecurrence, Value: "weekly"},
+		{ID: promptHolidayRecurrence, Value: "yearly"},
+		{ID: promptHolidayList, Value: "3"},
+		{ID: promptHolidayList, Value: "2"},
+	}
+	for _, a := range answers {
+		if !c.prompt.Active() || c.prompt.ID() != a.ID {
+			t.Fatalf("want prompt %v, got %v", a.ID, c.prompt.ID())
+		}
+		c = answer(t, c, a.Value)
+	}
+	if c.prompt.Active() {
+		t.Fatalf("expected every prompt to be answered")
+	}
+
+	b, err := os.ReadFile(birthdays)
+	if err != nil {
+		t.Fatalf("failed reading list: %v", err)
+	}
+	if want := "03-14 3 Pi day\n"; string(b) != want {
+		t.Fatalf("want: %q, got: %q", want, string(b))
+	}
+	h, ok := c.holidays.Match(day.AddDate(1, 0, 0))
+	if !ok || h.Message != "Pi day" {
+		t.Fatalf("expected the holidays to be reloaded, got: %+v", h)
+	}
+}
+
+// answer types a value into the calendar's prompt, replacing anything left by
+// an earlier invalid answer, and presses enter.
+func answer(t *testing.T, c Calendar, value string) Calendar {
+	t.Helper()
+	c, _ = c.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	if value != "" {
+		c, _ = c.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(value)})
+	}
+	c, cmd := c.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatalf("expected enter to submit the prompt")
+	}
+	c, _ = c.Update(cmd())
+	return c
+}
+
+func TestChooseList(t *testing.T) {
+	files := []string{"/a/public", "/b/birthdays"}
+	if got := listLabel(files); got != "List (1 public, 2 birthdays): " {
+		t.Fatalf("unexpected label: %q", got)
+	}
+	if got, err := chooseList(files, ""); err != nil || got != files[0] {
+		t.Fatalf("want the first list by default, got: %v %v", got, err)
+	}
+	if got, err := chooseList(files, "2"); err != nil || got != files[1] {
+		t.Fatalf("want the second list, got: %v %v", got, err)
+	}
+	for _, s := range []string{"0", "3", "public"} {
+		if _, err := chooseList(files, s); err == nil {
+			t.Fatalf("expected an error choosing %q", s)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package calendar
+
+import (
+	"fmt"
+	"time"
+
+	"git.sr.ht/~kota/calendar/index"
+	"git.sr.ht/~kota/calendar/keyword"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// indexMsg is a tea.Msg returned when the note index has been loaded and
+// brought up to date.
+type indexMsg struct {
+	index *index.Index
+	path  string
+	err   error
+}
+
+// loadIndex returns a tea.Cmd which loads the note index from the cache
+// directory, updates it with any notes changed since it was saved, and saves
+// it again. This happens in the background as it reads the note directory.
+func loadIndex(dir string, keywords keyword.Keywords) tea.Cmd {
+	return func() tea.Msg {
+		path, err := index.Path()
+		if err != nil {
+			return indexMsg{err: err}
+		}
+		ix, err := index.Load(path)
+		if err != nil {
+			return indexMsg{err: err}
+		}
+		if _, err := ix.Update(dir, keywords); err != nil {
+			return indexMsg{err: err}
+		}
+		return indexMsg{index: ix, path: path, err: ix.Save(path)}
+	}
+}
+
+// updateIndex brings the note index up to date with any notes changed outside
+// the calendar, saving it if anything changed. Only notes whose size or
+// modification time changed are read.
+func (c Calendar) updateIndex() error {
+	if c.index == nil {
+		return nil
+	}
+	stats, err := c.index.Update(c.config.NoteDir, c.config.Keywords)
+	if err != nil {
+		return fmt.Errorf("failed updating note index: %v", err)
+	}
+	if stats.Updated == 0 && stats.Removed == 0 {
+		return nil
+	}
+	if err := c.index.Save(c.indexPath); err != nil {
+		return fmt.Errorf("failed saving note index: %v", err)
+	}
+	return nil
+}
+
+// reindexDay updates the note index for a day whose note may have changed,
+// such as after it was edited.
+func (c Calendar) reindexDay(t time.Time) error {
+	if c.index == nil {
+		return nil
+	}
+	if err := c.index.UpdateDay(t); err != nil {
+		return fmt.Errorf("failed updating note index: %v", err)
+	}
+	if err := c.index.Save(c.indexPath); err != nil {
+		return fmt.Errorf("failed saving note index: %v", err)
+	}
+	return nil
+}
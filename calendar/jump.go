@@ -0,0 +1,47 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package calendar
+
+import "time"
+
+// maxJumps is the number of jumps remembered in the jumplist.
+const maxJumps = 100
+
+// jumplist is a history of large moves which can be walked back and forth,
+// much like the jumplist in vim.
+type jumplist struct {
+	jumps []time.Time
+	index int
+}
+
+// push records the day being jumped away from. Any jumps ahead of the current
+// position are forgotten.
+func (j *jumplist) push(from time.Time) {
+	j.jumps = append(j.jumps[:j.index], from)
+	if len(j.jumps) > maxJumps {
+		j.jumps = j.jumps[len(j.jumps)-maxJumps:]
+	}
+	j.index = len(j.jumps)
+}
+
+// back returns the previous day in the jumplist. The current day is recorded
+// when first moving back so that it can be returned to with forward.
+func (j *jumplist) back(current time.Time) (time.Time, bool) {
+	if j.index == 0 {
+		return time.Time{}, false
+	}
+	if j.index == len(j.jumps) {
+		j.jumps = append(j.jumps, current)
+	}
+	j.index--
+	return j.jumps[j.index], true
+}
+
+// forward returns the next day in the jumplist.
+func (j *jumplist) forward() (time.Time, bool) {
+	if j.index >= len(j.jumps)-1 {
+		return time.Time{}, false
+	}
+	j.index++
+	return j.jumps[j.index], true
+}
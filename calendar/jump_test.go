@@ -0,0 +1,54 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJumplist(t *testing.T) {
+	a := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	b := time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)
+	c := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	var j jumplist
+	if _, ok := j.back(a); ok {
+		t.Fatalf("expected an empty jumplist")
+	}
+
+	// Jump from a to b and then from b to c.
+	j.push(a)
+	j.push(b)
+
+	got, ok := j.back(c)
+	if !ok || !got.Equal(b) {
+		t.Fatalf("back: want: %v, got: %v", b, got)
+	}
+	got, ok = j.back(b)
+	if !ok || !got.Equal(a) {
+		t.Fatalf("back: want: %v, got: %v", a, got)
+	}
+	if _, ok := j.back(a); ok {
+		t.Fatalf("expected the start of the jumplist")
+	}
+
+	got, ok = j.forward()
+	if !ok || !got.Equal(b) {
+		t.Fatalf("forward: want: %v, got: %v", b, got)
+	}
+	got, ok = j.forward()
+	if !ok || !got.Equal(c) {
+		t.Fatalf("forward: want: %v, got: %v", c, got)
+	}
+	if _, ok := j.forward(); ok {
+		t.Fatalf("expected the end of the jumplist")
+	}
+
+	// Jumping from the middle forgets everything ahead.
+	j.back(c)
+	j.push(b)
+	if _, ok := j.forward(); ok {
+		t.Fatalf("expected the end of the jumplist after a new jump")
+	}
+}
@@ -0,0 +1,217 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package calendar
+
+import (
+	"strings"
+	"time"
+
+	"git.sr.ht/~kota/calendar/date"
+	"git.sr.ht/~kota/calendar/keyword"
+	"git.sr.ht/~kota/calendar/note"
+	"git.sr.ht/~kota/calendar/status"
+	tea "github.com/charmbracelet/bubbletea"
+	zone "github.com/lrstanley/bubblezone"
+)
+
+// zoneYear is the id of the zone marking the year above the twelve month grid.
+const zoneYear = "year"
+
+// doubleClickInterval is the longest time between two clicks on the same day
+// for them to count as a double click.
+const doubleClickInterval = 400 * time.Millisecond
+
+// hoverLines is the number of lines of a note shown when hovering its day.
+const hoverLines = 2
+
+// drag describes the left mouse button being held down on the days.
+type drag struct {
+	active bool
+	// start is the day the button was pressed on.
+	start time.Time
+	// moved is set once the mouse was dragged onto another day, which starts
+	// selecting a range.
+	moved bool
+}
+
+// click is the last time a day was clicked, used to notice double clicks.
+type click struct {
+	day time.Time
+	at  time.Time
+}
+
+// double reports if clicking a day at some time makes a double click.
+func (k click) double(day, at time.Time) bool {
+	return !k.at.IsZero() &&
+		date.Days(k.day, day) == 0 &&
+		at.Sub(k.at) < doubleClickInterval
+}
+
+// mouse handles a mouse event, which goes to whatever is under the mouse
+// rather than whatever is focused.
+func (c Calendar) mouse(msg tea.MouseMsg) (Calendar, tea.Cmd) {
+	if msg.Type != tea.MouseMotion {
+		c.hover = nil
+	}
+	switch msg.Type {
+	case tea.MouseMotion:
+		cmd := c.hoverAt(msg)
+		return c, cmd
+	case tea.MouseWheelUp:
+		return c.wheel(msg, -1)
+	case tea.MouseWheelDown:
+		return c.wheel(msg, 1)
+	case tea.MouseLeft:
+		if c.drag.active {
+			return c.dragTo(msg)
+		}
+		return c.press(msg)
+	case tea.MouseRelease:
+		c.drag = drag{}
+	}
+	return c, nil
+}
+
+// wheel scrolls the preview when the mouse is over it, switches the year or
+// month when over their headings, and otherwise moves the selection by a week.
+func (c Calendar) wheel(msg tea.MouseMsg, step int) (Calendar, tea.Cmd) {
+	if c.previewMode != previewModeHidden && c.preview.InBounds(msg) {
+		if step < 0 {
+			c.preview.LineUp(1)
+		} else {
+			c.preview.LineDown(1)
+		}
+		return c, nil
+	}
+	if len(c.months) == 12 && zone.Get(zoneYear).InBounds(msg) {
+		year := date.Month(
+			c.selected.Month(),
+			c.selected.Year()+step,
+			c.selected.Location(),
+		)
+		return c.Jump(date.InMonth(c.selected, year))
+	}
+	for _, m := range c.months {
+		if m.HeadingAt(msg) {
+			if step < 0 {
+				return c.Jump(date.LastMonth(c.selected))
+			}
+			return c.Jump(date.NextMonth(c.selected))
+		}
+	}
+	return c.Select(c.selected.AddDate(0, 0, 7*step))
+}
+
+// press handles the left mouse button being pressed. Clicking the preview
+// focuses it, clicking a month's heading selects that month, and clicking a
+// day selects it, or opens its note if it was clicked twice.
+func (c Calendar) press(msg tea.MouseMsg) (Calendar, tea.Cmd) {
+	if c.previewMode != previewModeHidden && c.preview.InBounds(msg) {
+		c.SetFocus(previewModeFocused)
+		return c, nil
+	}
+	for _, m := range c.months {
+		if m.HeadingAt(msg) {
+			if date.SameMonth(m.Date(), c.selected) {
+				return c, nil
+			}
+			return c.Jump(date.InMonth(c.selected, m.Date()))
+		}
+	}
+
+	t, ok := c.dayAt(msg)
+	if !ok {
+		return c, nil
+	}
+	now := time.Now()
+	double := c.click.double(t, now)
+	c.click = click{day: t, at: now}
+	c.drag = drag{active: true, start: t}
+	c, cmd := c.Select(t)
+	if double {
+		c.click = click{}
+		return c, tea.Batch(cmd, c.editNote())
+	}
+	return c, cmd
+}
+
+// dragTo handles the mouse moving with the left button held down. Dragging
+// onto another day selects the range of days from where the button was
+// pressed.
+func (c Calendar) dragTo(msg tea.MouseMsg) (Calendar, tea.Cmd) {
+	t, ok := c.dayAt(msg)
+	if !ok || date.Days(t, c.selected) == 0 {
+		return c, nil
+	}
+	if !c.drag.moved {
+		c.drag.moved = true
+		c.click = click{}
+		c.ranging = true
+		c.anchor = c.drag.start
+	}
+	return c.Select(t)
+}
+
+// dayAt returns the day under the mouse, if any.
+func (c Calendar) dayAt(msg tea.MouseMsg) (time.Time, bool) {
+	for _, m := range c.months {
+		if t, ok := m.DayAt(msg); ok {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// hoverMsg is the summary of a hovered day including its note.
+type hoverMsg struct{ hover *status.Hover }
+
+// hoverAt summarizes the day under the mouse for the status line. The summary
+// is kept until the mouse moves onto another day. The day's holidays are shown
+// at once while the returned tea.Cmd reads its note in the background.
+func (c *Calendar) hoverAt(msg tea.MouseMsg) tea.Cmd {
+	t, ok := c.dayAt(msg)
+	if !ok {
+		c.hover = nil
+		return nil
+	}
+	if c.hover != nil && date.Days(c.hover.Day, t) == 0 {
+		return nil
+	}
+	h := status.Hover{
+		Day:      t,
+		Holidays: c.holidays.MatchAll(t).Messages(),
+	}
+	c.hover = &h
+	dir := c.config.NoteDir
+	keywords := c.config.Keywords
+	return func() tea.Msg {
+		return hoverMsg{hover: summarize(h, dir, keywords)}
+	}
+}
+
+// summarize a day with the keywords in its note and the first lines of its
+// note.
+func summarize(
+	h status.Hover,
+	dir string,
+	keywords keyword.Keywords,
+) *status.Hover {
+	text, err := note.Read(h.Day, dir)
+	if err != nil {
+		return &h
+	}
+	for _, k := range keywords.MatchAll(strings.NewReader(text)) {
+		h.Keywords = append(h.Keywords, k.Keyword)
+	}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if len(h.Lines) == hoverLines {
+			break
+		}
+		h.Lines = append(h.Lines, line)
+	}
+	return &h
+}
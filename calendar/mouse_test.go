@@ -0,0 +1,35 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDoubleClick(t *testing.T) {
+	day := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC)
+	at := time.Date(2025, time.March, 10, 9, 30, 0, 0, time.UTC)
+	k := click{day: day, at: at}
+
+	type test struct {
+		day  time.Time
+		at   time.Time
+		want bool
+	}
+
+	tests := []test{
+		{day: day, at: at.Add(200 * time.Millisecond), want: true},
+		{day: day, at: at.Add(time.Second), want: false},
+		{day: day.AddDate(0, 0, 1), at: at.Add(200 * time.Millisecond), want: false},
+	}
+
+	for _, tc := range tests {
+		if got := k.double(tc.day, tc.at); got != tc.want {
+			t.Fatalf("clicking %v at %v: want: %v, got: %v", tc.day, tc.at, tc.want, got)
+		}
+	}
+	if (click{}).double(time.Time{}, at) {
+		t.Fatalf("expected the first click not to be a double click")
+	}
+}
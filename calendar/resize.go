@@ -37,8 +37,9 @@ func (c Calendar) resize() (Calendar, tea.Cmd) {
 		cmds = append(cmds, m.Init())
 	}
 
-	// Restore focus. It gets lost when resizing.
+	// Restore focus and the selected range. They get lost when resizing.
 	c.SetFocus(c.previewMode)
+	c.applyRange()
 	return c, tea.Batch(cmds...)
 }
 
@@ -49,6 +50,7 @@ func (c Calendar) resizeOne() []month.Month {
 		c.selected,
 		month.LayoutColumn,
 		c.holidays,
+		c.cache,
 		c.config,
 	)}
 }
@@ -64,6 +66,7 @@ func (c Calendar) resizeThree() []month.Month {
 				c.selected,
 				month.LayoutColumn,
 				c.holidays,
+				c.cache,
 				c.config,
 			),
 			month.New(
@@ -72,6 +75,7 @@ func (c Calendar) resizeThree() []month.Month {
 				c.selected,
 				month.LayoutColumn,
 				c.holidays,
+				c.cache,
 				c.config,
 			),
 			month.New(
@@ -80,6 +84,7 @@ func (c Calendar) resizeThree() []month.Month {
 				c.selected,
 				month.LayoutColumn,
 				c.holidays,
+				c.cache,
 				c.config,
 			),
 		}
@@ -91,6 +96,7 @@ func (c Calendar) resizeThree() []month.Month {
 				c.selected,
 				month.LayoutColumn,
 				c.holidays,
+				c.cache,
 				c.config,
 			),
 			month.New(
@@ -99,6 +105,7 @@ func (c Calendar) resizeThree() []month.Month {
 				c.selected,
 				month.LayoutColumn,
 				c.holidays,
+				c.cache,
 				c.config,
 			),
 			month.New(
@@ -107,6 +114,7 @@ func (c Calendar) resizeThree() []month.Month {
 				c.selected,
 				month.LayoutColumn,
 				c.holidays,
+				c.cache,
 				c.config,
 			),
 		}
@@ -118,6 +126,7 @@ func (c Calendar) resizeThree() []month.Month {
 				c.selected,
 				month.LayoutColumn,
 				c.holidays,
+				c.cache,
 				c.config,
 			),
 			month.New(
@@ -126,6 +135,7 @@ func (c Calendar) resizeThree() []month.Month {
 				c.selected,
 				month.LayoutColumn,
 				c.holidays,
+				c.cache,
 				c.config,
 			),
 			month.New(
@@ -134,6 +144,7 @@ func (c Calendar) resizeThree() []month.Month {
 				c.selected,
 				month.LayoutColumn,
 				c.holidays,
+				c.cache,
 				c.config,
 			),
 		}
@@ -143,7 +154,11 @@ func (c Calendar) resizeThree() []month.Month {
 func (c Calendar) resizeTwelve() []month.Month {
 	var months []month.Month
 	for i := 1; i <= 12; i++ {
-		m := date.Month(time.Month(i), c.selected.Year())
+		m := date.Month(
+			time.Month(i),
+			c.selected.Year(),
+			c.selected.Location(),
+		)
 		if date.SameMonth(m, c.selected) {
 			months = append(months, month.New(
 				m,
@@ -151,6 +166,7 @@ func (c Calendar) resizeTwelve() []month.Month {
 				c.selected,
 				month.LayoutGrid,
 				c.holidays,
+				c.cache,
 				c.config,
 			))
 		} else {
@@ -160,6 +176,7 @@ func (c Calendar) resizeTwelve() []month.Month {
 				c.selected,
 				month.LayoutGrid,
 				c.holidays,
+				c.cache,
 				c.config,
 			))
 		}
@@ -0,0 +1,51 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package clock
+
+import (
+	"fmt"
+	"time"
+)
+
+// Clock tells the current time. Everything which needs to know what time it
+// is, such as which day is today, asks a Clock rather than calling time.Now so
+// the time can be pinned for demos and tests.
+type Clock interface {
+	Now() time.Time
+}
+
+// System is the computer's real clock.
+type System struct{}
+
+// Now returns the current time.
+func (System) Now() time.Time {
+	return time.Now()
+}
+
+// Frozen is a clock which always reads the same time.
+type Frozen time.Time
+
+// Now returns the frozen time.
+func (f Frozen) Now() time.Time {
+	return time.Time(f)
+}
+
+// Or returns the clock, or the System clock if it is nil.
+func Or(c Clock) Clock {
+	if c == nil {
+		return System{}
+	}
+	return c
+}
+
+// Parse reads a time to freeze a clock at, given as 2006-01-02 for midnight
+// or 2006-01-02T15:04 for a time of day, in a location.
+func Parse(s string, location *time.Location) (Frozen, error) {
+	for _, layout := range []string{"2006-01-02", "2006-01-02T15:04"} {
+		t, err := time.ParseInLocation(layout, s, location)
+		if err == nil {
+			return Frozen(t), nil
+		}
+	}
+	return Frozen{}, fmt.Errorf("invalid date %v: want 2006-01-02 or 2006-01-02T15:04", s)
+}
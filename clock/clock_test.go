@@ -0,0 +1,39 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	auckland, err := time.LoadLocation("Pacific/Auckland")
+	if err != nil {
+		t.Fatalf("failed loading Pacific/Auckland timezone: %v", err)
+	}
+
+	tests := map[string]time.Time{
+		"2025-12-25":       time.Date(2025, time.December, 25, 0, 0, 0, 0, auckland),
+		"2025-12-25T09:30": time.Date(2025, time.December, 25, 9, 30, 0, 0, auckland),
+	}
+	for s, want := range tests {
+		c, err := Parse(s, auckland)
+		if err != nil {
+			t.Fatalf("failed parsing %v: %v", s, err)
+		}
+		// A frozen clock never moves.
+		for i := 0; i < 2; i++ {
+			if got := c.Now(); !got.Equal(want) || got.Location() != auckland {
+				t.Fatalf("%v want: %v, got: %v", s, want, got)
+			}
+		}
+	}
+
+	if _, err := Parse("25/12/2025", auckland); err == nil {
+		t.Fatalf("expected an error parsing an invalid date")
+	}
+	if _, ok := Or(nil).(System); !ok {
+		t.Fatalf("expected a nil clock to be the system clock")
+	}
+}
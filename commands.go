@@ -0,0 +1,144 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"git.sr.ht/~kota/calendar/clock"
+	"git.sr.ht/~kota/calendar/config"
+	"git.sr.ht/~kota/calendar/countdown"
+	"git.sr.ht/~kota/calendar/holiday"
+	"git.sr.ht/~kota/calendar/index"
+	"git.sr.ht/~kota/calendar/workday"
+)
+
+// command is a non-interactive subcommand which writes its output to w. The
+// clock tells the command what day today is.
+type command func(
+	args []string,
+	conf *config.Config,
+	clk clock.Clock,
+	w io.Writer,
+) error
+
+// commands maps subcommand names to their implementations.
+var commands = map[string]command{
+	"workdays":  workdays,
+	"countdown": countdowns,
+	"reindex":   reindex,
+}
+
+// loadHolidays loads the configured holiday lists, warning on standard error
+// about any lines which failed to parse.
+func loadHolidays(conf *config.Config, clk clock.Clock) holiday.Holidays {
+	holidays, err := holiday.Load(conf.HolidayLists, conf.HolidayOptions(clk))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	return holidays
+}
+
+// workdays prints the number of business days between two dates.
+func workdays(
+	args []string,
+	conf *config.Config,
+	clk clock.Clock,
+	w io.Writer,
+) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: calendar workdays FROM TO")
+	}
+	from, err := time.ParseInLocation("2006-01-02", args[0], conf.Location())
+	if err != nil {
+		return fmt.Errorf("invalid date %v: %v", args[0], err)
+	}
+	to, err := time.ParseInLocation("2006-01-02", args[1], conf.Location())
+	if err != nil {
+		return fmt.Errorf("invalid date %v: %v", args[1], err)
+	}
+
+	holidays := loadHolidays(conf, clk)
+	schedule := workday.New(conf.Weekend, holidays)
+	_, err = fmt.Fprintln(w, schedule.Count(from, to))
+	return err
+}
+
+// countdowns prints the upcoming countdown events, one per line, nearest first.
+// An optional argument limits the number of events printed.
+func countdowns(
+	args []string,
+	conf *config.Config,
+	clk clock.Clock,
+	w io.Writer,
+) error {
+	limit := -1
+	switch len(args) {
+	case 0:
+	case 1:
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid number of events %v", args[0])
+		}
+		limit = n
+	default:
+		return fmt.Errorf("usage: calendar countdown [COUNT]")
+	}
+
+	holidays := loadHolidays(conf, clk)
+	events := countdown.Upcoming(
+		clk.Now().In(conf.Location()),
+		holidays,
+		conf.Keywords,
+		workday.New(conf.Weekend, holidays),
+		conf.NoteDir,
+		nil,
+	)
+	for i, e := range events {
+		if i == limit {
+			break
+		}
+		if _, err := fmt.Fprintln(w, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reindex rebuilds the note index from scratch, reading every note, and prints
+// a summary of it.
+func reindex(
+	args []string,
+	conf *config.Config,
+	clk clock.Clock,
+	w io.Writer,
+) error {
+	if len(args) != 0 {
+		return fmt.Errorf("usage: calendar reindex")
+	}
+	path, err := index.Path()
+	if err != nil {
+		return err
+	}
+	ix := index.New()
+	stats, err := ix.Update(conf.NoteDir, conf.Keywords)
+	if err != nil {
+		return fmt.Errorf("failed indexing notes: %v", err)
+	}
+	if err := ix.Save(path); err != nil {
+		return fmt.Errorf("failed saving index: %v", err)
+	}
+	_, err = fmt.Fprintf(
+		w,
+		"Indexed %v\nnotes: %v\ntags: %v\nwords: %v\n",
+		path,
+		stats.Notes,
+		stats.Tags,
+		stats.Words,
+	)
+	return err
+}
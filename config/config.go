@@ -4,9 +4,15 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"io/fs"
 	"os"
+	"strings"
+	"time"
 
+	"git.sr.ht/~kota/calendar/clock"
+	"git.sr.ht/~kota/calendar/date/system"
+	"git.sr.ht/~kota/calendar/holiday"
 	"git.sr.ht/~kota/calendar/keyword"
 	"github.com/BurntSushi/toml"
 	"github.com/charmbracelet/lipgloss"
@@ -18,6 +24,11 @@ type Config struct {
 	TodayStyle           Style
 	InactiveStyle        Style
 	NotedStyle           Style
+	RangeStyle           Style
+	NewMoonStyle         Style
+	FullMoonStyle        Style
+	StatusStyle          Style
+	ErrorStyle           Style
 	NoteDir              string
 	Editor               string
 	LeftPadding          int
@@ -38,20 +49,59 @@ type Config struct {
 	KeyScrollPreviewUp   Control
 	KeyEditNote          Control
 	KeyYankDate          Control
+	KeyYankPicker        Control
 	KeyLastSunday        Control
 	KeyNextSunday        Control
 	KeyNextSaturday      Control
 	KeyMonthUp           Control
 	KeyMonthDown         Control
+	KeyNextWorkday       Control
+	KeyLastWorkday       Control
+	KeyGoto              Control
+	KeyRange             Control
+	KeyViewRange         Control
+	KeyExportRange       Control
+	KeyMark              Control
+	KeyJumpMark          Control
+	KeyJumpBack          Control
+	KeyJumpForward       Control
+	KeyNextNote          Control
+	KeyLastNote          Control
+	KeyNextHoliday       Control
+	KeyLastHoliday       Control
+	KeyNextKeyword       Control
+	KeyLastKeyword       Control
+	KeySearch            Control
+	KeyAddHoliday        Control
+	KeyEditHolidays      Control
+	Weekend              Weekdays
+	YankFormats          []YankFormat
+	Clipboard            string
+	TimeZone             string
+	Locale               string
+	AltCalendar          string
+	Latitude             float64
+	Longitude            float64
+	FollowToday          bool
+	Zones                []Zone
 	HolidayLists         []string
+	DayOffLists          []string
+	CountdownLists       []string
+	BirthdayLists        []string
+	AnniversaryLists     []string
+	HolidayPrecedence    []string
+	LeapDayFallback      string
 	Keywords             keyword.Keywords
+	// location is the TimeZone, resolved once by Load.
+	location *time.Location
 }
 
 // Style represents how a type of date should be displayed.
 type Style struct {
-	Color  string
-	Bold   bool
-	Italic bool
+	Color      string
+	Background string
+	Bold       bool
+	Italic     bool
 }
 
 // Export takes a base lipgloss.Style and makes the changes needed based on this configured Style.
@@ -59,14 +109,17 @@ func (s Style) Export(base lipgloss.Style) lipgloss.Style {
 	if s.Color != "" {
 		base = base.Foreground(lipgloss.Color(s.Color))
 	}
+	if s.Background != "" {
+		base = base.Background(lipgloss.Color(s.Background))
+	}
 	base = base.Bold(s.Bold)
 	base = base.Italic(s.Italic)
 	return base
 }
 
-// Blank returns true if the Style has no color and is not bold or italicized.
+// Blank returns true if the Style has no colors and is not bold or italicized.
 func (s Style) Blank() bool {
-	if s.Color != "" {
+	if s.Color != "" || s.Background != "" {
 		return false
 	}
 	if s.Bold {
@@ -78,6 +131,24 @@ func (s Style) Blank() bool {
 	return true
 }
 
+// YankFormat describes a way of copying the selected day into the clipboard.
+//
+// Format is either a Go time layout string such as "2006-01-02" or one of the
+// special values "RFC3339", "Unix", "Content" (the note's text), or "Path"
+// (the note's file path).
+type YankFormat struct {
+	Name   string
+	Format string
+	Keys   Control
+}
+
+// Zone describes a secondary time zone shown in the status line.
+type Zone struct {
+	Name         string
+	TimeZone     string
+	HolidayLists []string
+}
+
 // Control is a slice of strings representing the keys bound to a given action.
 type Control []string
 
@@ -91,12 +162,63 @@ func (c Control) Contains(key string) bool {
 	return false
 }
 
+// Weekdays is a slice of weekday names such as "Saturday" or "Sun".
+type Weekdays []string
+
+// Contains reports if a given weekday is in the list. Names are compared
+// without regard to case and may be given in full or as the first three
+// letters.
+func (w Weekdays) Contains(d time.Weekday) bool {
+	for _, name := range w {
+		if strings.EqualFold(name, d.String()) ||
+			strings.EqualFold(name, d.String()[:3]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Location returns the configured time zone or, if none is configured, the
+// local time zone. The zone is resolved by Load, so it is only looked up here
+// for configurations created some other way.
+func (c *Config) Location() *time.Location {
+	if c.location != nil {
+		return c.location
+	}
+	if c.TimeZone == "" {
+		return time.Local
+	}
+	location, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		return time.Local
+	}
+	return location
+}
+
+// HolidayOptions returns the options used to load the HolidayLists with a
+// clock telling the current year.
+func (c *Config) HolidayOptions(clk clock.Clock) holiday.Options {
+	return holiday.Options{
+		DayOff:          c.DayOffLists,
+		Countdown:       c.CountdownLists,
+		Birthday:        c.BirthdayLists,
+		Anniversary:     c.AnniversaryLists,
+		Precedence:      c.HolidayPrecedence,
+		LeapDayFallback: c.LeapDayFallback,
+		Location:        c.Location(),
+		Clock:           clk,
+	}
+}
+
 // Default returns the default configuration.
 func Default() *Config {
 	return &Config{
 		TodayStyle:        Style{Color: "2"},
 		InactiveStyle:     Style{Color: "8"},
 		NotedStyle:        Style{},
+		RangeStyle:        Style{Color: "4"},
+		StatusStyle:       Style{Color: "8"},
+		ErrorStyle:        Style{Color: "1"},
 		LeftPadding:       2,
 		RightPadding:      1,
 		NoteDir:           "$HOME/.local/share/calendar",
@@ -115,12 +237,43 @@ func Default() *Config {
 		KeyTogglePreview:  []string{"p"},
 		KeyEditNote:       []string{"enter"},
 		KeyYankDate:       []string{"y"},
+		KeyYankPicker:     []string{"Y"},
 		KeyLastSunday:     []string{"b", "H"},
 		KeyNextSunday:     []string{"w"},
 		KeyNextSaturday:   []string{"e", "L"},
 		KeyMonthUp:        []string{"ctrl+u"},
 		KeyMonthDown:      []string{"ctrl+d"},
-		HolidayLists:      []string{""},
+		KeyNextWorkday:    []string{"n"},
+		KeyLastWorkday:    []string{"N"},
+		KeyGoto:           []string{"g"},
+		KeyRange:          []string{"v"},
+		KeyViewRange:      []string{"o"},
+		KeyExportRange:    []string{"x"},
+		KeyMark:           []string{"m"},
+		KeyJumpMark:       []string{"'", "`"},
+		KeyJumpBack:       []string{"ctrl+o"},
+		KeyJumpForward:    []string{"ctrl+n"},
+		KeyNextNote:       []string{"}"},
+		KeyLastNote:       []string{"{"},
+		KeyNextHoliday:    []string{"]"},
+		KeyLastHoliday:    []string{"["},
+		KeyNextKeyword:    []string{")"},
+		KeyLastKeyword:    []string{"("},
+		KeySearch:         []string{"/"},
+		KeyAddHoliday:     []string{"a"},
+		KeyEditHolidays:   []string{"A"},
+		Weekend:           []string{"Saturday", "Sunday"},
+		YankFormats: []YankFormat{
+			{Name: "Date", Format: "2006-01-02"},
+			{Name: "RFC 3339", Format: "RFC3339"},
+			{Name: "Unix", Format: "Unix"},
+			{Name: "Short", Format: "Mon 2 Jan"},
+			{Name: "Note", Format: "Content"},
+			{Name: "Path", Format: "Path"},
+		},
+		Clipboard:       "auto",
+		HolidayLists:    []string{""},
+		LeapDayFallback: "02-28",
 	}
 }
 
@@ -134,6 +287,12 @@ func Load() (*Config, error) {
 	if visvar, ok := os.LookupEnv("VISUAL"); ok {
 		conf.Editor = visvar
 	}
+	for _, v := range []string{"LC_ALL", "LC_TIME", "LANG"} {
+		if l := os.Getenv(v); l != "" {
+			conf.Locale = l
+			break
+		}
+	}
 
 	scope := gap.NewScope(gap.User, "calendar")
 	configPath, err := scope.ConfigPath("config.toml")
@@ -145,5 +304,31 @@ func Load() (*Config, error) {
 	if err != nil && !errors.Is(err, fs.ErrNotExist) {
 		return nil, err
 	}
+
+	conf.location = time.Local
+	if conf.TimeZone != "" {
+		conf.location, err = time.LoadLocation(conf.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TimeZone: %v", err)
+		}
+	}
+	switch conf.LeapDayFallback {
+	case "", "02-28", "03-01":
+	default:
+		return nil, fmt.Errorf(
+			"invalid LeapDayFallback: %v",
+			conf.LeapDayFallback,
+		)
+	}
+	if conf.AltCalendar != "" {
+		if _, ok := system.Get(conf.AltCalendar); !ok {
+			return nil, fmt.Errorf("invalid AltCalendar: %v", conf.AltCalendar)
+		}
+	}
+	for _, z := range conf.Zones {
+		if _, err := time.LoadLocation(z.TimeZone); err != nil {
+			return nil, fmt.Errorf("invalid TimeZone for %v: %v", z.Name, err)
+		}
+	}
 	return conf, nil
 }
@@ -0,0 +1,134 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package countdown
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"git.sr.ht/~kota/calendar/date"
+	"git.sr.ht/~kota/calendar/holiday"
+	"git.sr.ht/~kota/calendar/index"
+	"git.sr.ht/~kota/calendar/keyword"
+	"git.sr.ht/~kota/calendar/locale"
+	"git.sr.ht/~kota/calendar/note"
+	"git.sr.ht/~kota/calendar/workday"
+)
+
+// Event is an upcoming day being counted down to.
+type Event struct {
+	Name     string
+	Date     time.Time
+	Days     int
+	Workdays int
+}
+
+// Describe describes the event in a locale, such as "Release in 12 days / 9
+// business days".
+func (e Event) Describe(l locale.Locale) string {
+	if e.Days <= 1 {
+		return e.Name + " " + l.Relative(e.Days)
+	}
+	return fmt.Sprintf(
+		"%v %v / %v",
+		e.Name,
+		l.Relative(e.Days),
+		l.Count(l.BusinessDays, e.Workdays),
+	)
+}
+
+// Upcoming returns the events on or after today in the order they occur.
+//
+// Events are the next occurrence of each holiday marked as a countdown and
+// every future note containing a keyword marked as a countdown. Keyword events
+// are named after the line containing the keyword. The notes are looked up in
+// the note index, or read from the directory if the index is nil.
+func Upcoming(
+	today time.Time,
+	holidays holiday.Holidays,
+	keywords keyword.Keywords,
+	schedule workday.Schedule,
+	dir string,
+	ix *index.Index,
+) []Event {
+	today = time.Date(
+		today.Year(), today.Month(), today.Day(),
+		0, 0, 0, 0,
+		today.Location(),
+	)
+
+	var events []Event
+	for _, h := range holidays {
+		if !h.Countdown {
+			continue
+		}
+		if t, ok := h.Next(today); ok {
+			events = append(events, newEvent(h.On(t).Message, today, t, schedule))
+		}
+	}
+
+	var ks keyword.Keywords
+	for _, k := range keywords {
+		if k.Countdown {
+			ks = append(ks, k)
+		}
+	}
+	if len(ks) > 0 {
+		var dates []time.Time
+		if ix == nil {
+			dates, _ = note.Dates(dir, today.Location())
+		} else {
+			dates = ix.Dates(today.Location())
+		}
+		for _, t := range dates {
+			if t.Before(today) {
+				continue
+			}
+			if line, ok := matchLine(ks, t, dir, ix); ok {
+				events = append(events, newEvent(line, today, t, schedule))
+			}
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Date.Before(events[j].Date)
+	})
+	return events
+}
+
+// newEvent creates an event for time t counted from today.
+func newEvent(name string, today, t time.Time, schedule workday.Schedule) Event {
+	return Event{
+		Name:     name,
+		Date:     t,
+		Days:     date.Days(today, t),
+		Workdays: schedule.Count(today, t),
+	}
+}
+
+// matchLine returns the trimmed line containing a keyword in the note for time
+// t, from the note index unless it is nil.
+func matchLine(
+	ks keyword.Keywords,
+	t time.Time,
+	dir string,
+	ix *index.Index,
+) (string, bool) {
+	if ix != nil {
+		n, _ := ix.Note(t)
+		_, line, ok := n.Match(ks)
+		return line, ok
+	}
+
+	f, err := os.Open(note.Path(t, dir))
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+
+	_, line, ok := ks.MatchLine(f)
+	return strings.TrimSpace(line), ok
+}
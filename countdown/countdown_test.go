@@ -0,0 +1,103 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package countdown
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"git.sr.ht/~kota/calendar/config"
+	"git.sr.ht/~kota/calendar/holiday"
+	"git.sr.ht/~kota/calendar/index"
+	"git.sr.ht/~kota/calendar/keyword"
+	"git.sr.ht/~kota/calendar/locale"
+	"git.sr.ht/~kota/calendar/workday"
+)
+
+func TestUpcoming(t *testing.T) {
+	dir := t.TempDir()
+	notes := map[string]string{
+		"2022-12-01.md": "DEADLINE: already gone",
+		"2023-01-20.md": "Some notes.\nDEADLINE: ship 1.4\n",
+		"2023-01-25.md": "Nothing to count.",
+	}
+	for name, content := range notes {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644)
+		if err != nil {
+			t.Fatalf("failed writing %v: %v", name, err)
+		}
+	}
+
+	holidays := holiday.Holidays{
+		{Date: "0000-12-25", Message: "Christmas", Countdown: true},
+		{Date: "2023-01-09", Message: "Release 1.4", Countdown: true},
+		{Date: "0000-01-10", Message: "Not counted"},
+	}
+	keywords := keyword.Keywords{
+		{Keyword: "DEADLINE", Countdown: true},
+		{Keyword: "Nothing"},
+	}
+	schedule := workday.New(config.Weekdays{"Saturday", "Sunday"}, nil)
+	today := time.Date(2023, time.January, 2, 9, 30, 0, 0, time.UTC)
+
+	ix := index.New()
+	if _, err := ix.Update(dir, keywords); err != nil {
+		t.Fatalf("failed updating index: %v", err)
+	}
+	want := []string{
+		"Release 1.4 in 7 days / 5 business days",
+		"DEADLINE: ship 1.4 in 18 days / 14 business days",
+		"Christmas in 357 days / 255 business days",
+	}
+	// The notes are the same whether read from the directory or the index.
+	for _, ix := range []*index.Index{nil, ix} {
+		got := Upcoming(today, holidays, keywords, schedule, dir, ix)
+		if len(got) != len(want) {
+			t.Fatalf("want: %v events, got: %v", len(want), got)
+		}
+		for i := range want {
+			if s := got[i].Describe(locale.English); s != want[i] {
+				t.Fatalf("want: %q, got: %q", want[i], s)
+			}
+		}
+	}
+}
+
+func TestEventString(t *testing.T) {
+	type test struct {
+		event  Event
+		locale locale.Locale
+		want   string
+	}
+
+	tests := []test{
+		{
+			event:  Event{Name: "Launch", Days: 0},
+			locale: locale.English,
+			want:   "Launch today",
+		},
+		{
+			event:  Event{Name: "Launch", Days: 1, Workdays: 1},
+			locale: locale.English,
+			want:   "Launch tomorrow",
+		},
+		{
+			event:  Event{Name: "Launch", Days: 3, Workdays: 1},
+			locale: locale.English,
+			want:   "Launch in 3 days / 1 business day",
+		},
+		{
+			event:  Event{Name: "Start", Days: 3, Workdays: 2},
+			locale: locale.Get("de"),
+			want:   "Start in 3 Tagen / 2 Werktage",
+		},
+	}
+
+	for _, tc := range tests {
+		if got := tc.event.Describe(tc.locale); got != tc.want {
+			t.Fatalf("want: %q, got: %q", tc.want, got)
+		}
+	}
+}
@@ -4,10 +4,10 @@ package date
 
 import "time"
 
-// Month returns a time for a given month and year. The time value represents
-// the first day of that month.
-func Month(m time.Month, year int) time.Time {
-	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+// Month returns a time for a given month and year in the given location. The
+// time value represents the first day of that month.
+func Month(m time.Month, year int, location *time.Location) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, location)
 }
 
 // LastMonth returns a time representing the previous month from time t.
@@ -32,6 +32,17 @@ func NextMonth(t time.Time) time.Time {
 	return time.Date(t.Year(), t.Month()+1, day, 0, 0, 0, 0, t.Location())
 }
 
+// InMonth returns a time representing the same day of the month as time t in
+// the month of time m. The day will be truncated to the last day if needed.
+func InMonth(t, m time.Time) time.Time {
+	day := t.Day()
+	lastDay := DaysIn(m.Month(), m.Year())
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(m.Year(), m.Month(), day, 0, 0, 0, 0, t.Location())
+}
+
 // DaysIn reports the number of days in the month of time t.
 func DaysIn(m time.Month, year int) int {
 	// The reason it works is that we generate a date one month from the target,
@@ -117,3 +128,11 @@ func LastWeek(t time.Time) bool {
 func FirstWeek(t time.Time) bool {
 	return t.AddDate(0, 0, -7).Month() != t.Month()
 }
+
+// Days returns the number of calendar days from x to y, ignoring the time of
+// day and any daylight saving changes. The result is negative if y is before x.
+func Days(x, y time.Time) int {
+	x = time.Date(x.Year(), x.Month(), x.Day(), 0, 0, 0, 0, time.UTC)
+	y = time.Date(y.Year(), y.Month(), y.Day(), 0, 0, 0, 0, time.UTC)
+	return int(y.Sub(x).Hours() / 24)
+}
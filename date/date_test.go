@@ -60,3 +60,76 @@ func TestSameMonth(t *testing.T) {
 		}
 	}
 }
+
+func TestInMonth(t *testing.T) {
+	type test struct {
+		t    time.Time
+		m    time.Time
+		want time.Time
+	}
+
+	tests := []test{
+		{
+			t:    time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC),
+			m:    time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, time.December, 14, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			t:    time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC),
+			m:    time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range tests {
+		got := InMonth(tc.t, tc.m)
+		if !got.Equal(tc.want) {
+			t.Fatalf("want: %v, got: %v", tc.want, got)
+		}
+	}
+}
+
+func TestDays(t *testing.T) {
+	auckland, err := time.LoadLocation("Pacific/Auckland")
+	if err != nil {
+		t.Fatalf("failed loading Pacific/Auckland timezone: %v", err)
+	}
+
+	type test struct {
+		x    time.Time
+		y    time.Time
+		want int
+	}
+
+	tests := []test{
+		{
+			x:    time.Date(2023, 1, 1, 23, 0, 0, 0, time.UTC),
+			y:    time.Date(2023, 1, 2, 1, 0, 0, 0, time.UTC),
+			want: 1,
+		},
+		{
+			x:    time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+			y:    time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+			want: -28,
+		},
+		{
+			// Crosses the end of daylight saving time.
+			x:    time.Date(2023, 4, 1, 0, 0, 0, 0, auckland),
+			y:    time.Date(2023, 4, 3, 0, 0, 0, 0, auckland),
+			want: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		got := Days(tc.x, tc.y)
+		if got != tc.want {
+			t.Fatalf(
+				"input:\n%v\n%v\nwant: %v, got: %v\n",
+				tc.x,
+				tc.y,
+				tc.want,
+				got,
+			)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package system
+
+import (
+	"fmt"
+	"time"
+)
+
+// Hebrew is the arithmetic Hebrew calendar. Months are numbered from Nisan as
+// 1 so Tishri, the first month of the year, is 7. Adar is 12 and in leap years
+// Adar II is 13.
+type Hebrew struct{}
+
+// hebrewEpoch is the fixed day of 1 Tishri 1 AM, 7 October 3761 BCE in the
+// Julian calendar.
+const hebrewEpoch = -1373427
+
+const (
+	nisan  = 1
+	tishri = 7
+)
+
+var hebrewMonths = [13]string{
+	"Nisan", "Iyyar", "Sivan", "Tammuz", "Av", "Elul", "Tishri",
+	"Marheshvan", "Kislev", "Tevet", "Shevat", "Adar", "Adar II",
+}
+
+// Name of the calendar system.
+func (Hebrew) Name() string {
+	return "hebrew"
+}
+
+// Limits returns the largest month and day of a month any year may have.
+// Leap years have a thirteenth month, Adar II.
+func (Hebrew) Limits() (int, int) {
+	return 13, 30
+}
+
+// hebrewLeap reports if a Hebrew year has a thirteenth month.
+func hebrewLeap(year int) bool {
+	return mod(7*year+1, 19) < 7
+}
+
+// lastHebrewMonth returns the number of months in a Hebrew year.
+func lastHebrewMonth(year int) int {
+	if hebrewLeap(year) {
+		return 13
+	}
+	return 12
+}
+
+// hebrewElapsedDays returns the number of days from the epoch to the molad of
+// Tishri in a year, delayed if it falls on a Sunday, Wednesday, or Friday.
+func hebrewElapsedDays(year int) int {
+	months := floorDiv(235*year-234, 19)
+	parts := 12084 + 13753*months
+	days := 29*months + floorDiv(parts, 25920)
+	if mod(3*(days+1), 7) < 3 {
+		days++
+	}
+	return days
+}
+
+// hebrewYearDelay returns the days the new year is delayed to keep the
+// length of the year and the previous year valid.
+func hebrewYearDelay(year int) int {
+	last := hebrewElapsedDays(year - 1)
+	this := hebrewElapsedDays(year)
+	next := hebrewElapsedDays(year + 1)
+	switch {
+	case next-this == 356:
+		return 2
+	case this-last == 382:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// hebrewNewYear returns the fixed day of 1 Tishri in a year.
+func hebrewNewYear(year int) int {
+	return hebrewEpoch + hebrewElapsedDays(year) + hebrewYearDelay(year)
+}
+
+// hebrewYearDays returns the number of days in a Hebrew year.
+func hebrewYearDays(year int) int {
+	return hebrewNewYear(year+1) - hebrewNewYear(year)
+}
+
+// hebrewMonthDays returns the number of days in a month of a Hebrew year.
+func hebrewMonthDays(month, year int) int {
+	switch {
+	case month == 2, month == 4, month == 6, month == 10, month == 13:
+		return 29
+	case month == 12 && !hebrewLeap(year):
+		return 29
+	case month == 8 && hebrewYearDays(year)%10 != 5:
+		// Marheshvan is long only in complete years of 355 or 385 days.
+		return 29
+	case month == 9 && hebrewYearDays(year)%10 == 3:
+		// Kislev is short in deficient years of 353 or 383 days.
+		return 29
+	default:
+		return 30
+	}
+}
+
+// fixedFromHebrew returns the fixed day of a Hebrew date.
+func fixedFromHebrew(d Date) int {
+	day := hebrewNewYear(d.Year) + d.Day - 1
+	if d.Month < tishri {
+		for m := tishri; m <= lastHebrewMonth(d.Year); m++ {
+			day += hebrewMonthDays(m, d.Year)
+		}
+		for m := nisan; m < d.Month; m++ {
+			day += hebrewMonthDays(m, d.Year)
+		}
+	} else {
+		for m := tishri; m < d.Month; m++ {
+			day += hebrewMonthDays(m, d.Year)
+		}
+	}
+	return day
+}
+
+// hebrewFromFixed returns the Hebrew date of a fixed day.
+func hebrewFromFixed(day int) Date {
+	// The average length of a year is 35975351/98496 days.
+	year := floorDiv((day-hebrewEpoch)*98496, 35975351)
+	for hebrewNewYear(year+1) <= day {
+		year++
+	}
+	month := nisan
+	if day < fixedFromHebrew(Date{Year: year, Month: nisan, Day: 1}) {
+		month = tishri
+	}
+	for day > fixedFromHebrew(Date{
+		Year:  year,
+		Month: month,
+		Day:   hebrewMonthDays(month, year),
+	}) {
+		month++
+	}
+	return Date{
+		Year:  year,
+		Month: month,
+		Day:   day - fixedFromHebrew(Date{Year: year, Month: month, Day: 1}) + 1,
+	}
+}
+
+// FromTime converts the day of time t to a Hebrew date.
+func (Hebrew) FromTime(t time.Time) Date {
+	return hebrewFromFixed(fixed(t))
+}
+
+// ToTime converts a Hebrew date to midnight on that day.
+func (Hebrew) ToTime(d Date, location *time.Location) time.Time {
+	return fromFixed(fixedFromHebrew(d), location)
+}
+
+// Format describes a Hebrew date, such as "7 Kislev 5706". In leap years Adar
+// is written as Adar I.
+func (Hebrew) Format(d Date) string {
+	name := hebrewMonths[d.Month-1]
+	if d.Month == 12 && hebrewLeap(d.Year) {
+		name = "Adar I"
+	}
+	return fmt.Sprintf("%v %v %v", d.Day, name, d.Year)
+}
@@ -0,0 +1,69 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package system
+
+import (
+	"fmt"
+	"time"
+)
+
+// Islamic is the arithmetic (tabular) Islamic calendar. Religious observance
+// usually follows sightings of the moon, which may differ by a day or two.
+type Islamic struct{}
+
+// islamicEpoch is the fixed day of 1 Muharram 1 AH, 16 July 622 in the Julian
+// calendar.
+const islamicEpoch = 227015
+
+var islamicMonths = [12]string{
+	"Muharram", "Safar", "Rabi' al-Awwal", "Rabi' al-Thani",
+	"Jumada al-Ula", "Jumada al-Akhirah", "Rajab", "Sha'ban",
+	"Ramadan", "Shawwal", "Dhu al-Qa'dah", "Dhu al-Hijjah",
+}
+
+// Name of the calendar system.
+func (Islamic) Name() string {
+	return "islamic"
+}
+
+// Limits returns the largest month and day of a month any year may have.
+func (Islamic) Limits() (int, int) {
+	return 12, 30
+}
+
+// fixedFromIslamic returns the fixed day of an Islamic date.
+func fixedFromIslamic(d Date) int {
+	return islamicEpoch - 1 +
+		(d.Year-1)*354 +
+		floorDiv(3+11*d.Year, 30) +
+		29*(d.Month-1) +
+		floorDiv(d.Month, 2) +
+		d.Day
+}
+
+// islamicFromFixed returns the Islamic date of a fixed day.
+func islamicFromFixed(day int) Date {
+	year := floorDiv(30*(day-islamicEpoch)+10646, 10631)
+	prior := day - fixedFromIslamic(Date{Year: year, Month: 1, Day: 1})
+	month := floorDiv(11*prior+330, 325)
+	return Date{
+		Year:  year,
+		Month: month,
+		Day:   day - fixedFromIslamic(Date{Year: year, Month: month, Day: 1}) + 1,
+	}
+}
+
+// FromTime converts the day of time t to an Islamic date.
+func (Islamic) FromTime(t time.Time) Date {
+	return islamicFromFixed(fixed(t))
+}
+
+// ToTime converts an Islamic date to midnight on that day.
+func (Islamic) ToTime(d Date, location *time.Location) time.Time {
+	return fromFixed(fixedFromIslamic(d), location)
+}
+
+// Format describes an Islamic date, such as "6 Dhu al-Hijjah 1364 AH".
+func (Islamic) Format(d Date) string {
+	return fmt.Sprintf("%v %v %v AH", d.Day, islamicMonths[d.Month-1], d.Year)
+}
@@ -0,0 +1,55 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package system
+
+import (
+	"fmt"
+	"time"
+)
+
+// ISO is the ISO 8601 week date calendar. The month of a Date is the week
+// number and the day is the weekday, counting Monday as 1 and Sunday as 7.
+type ISO struct{}
+
+// Name of the calendar system.
+func (ISO) Name() string {
+	return "iso"
+}
+
+// Limits returns the largest month and day of a month any year may have.
+// A year has at most 53 weeks of 7 days.
+func (ISO) Limits() (int, int) {
+	return 53, 7
+}
+
+// FromTime converts the day of time t to an ISO week date.
+func (ISO) FromTime(t time.Time) Date {
+	year, week := t.ISOWeek()
+	day := int(t.Weekday())
+	if day == 0 {
+		day = 7
+	}
+	return Date{Year: year, Month: week, Day: day}
+}
+
+// ToTime converts an ISO week date to midnight on that day.
+func (ISO) ToTime(d Date, location *time.Location) time.Time {
+	// The 4th of January is always in the first week.
+	jan4 := time.Date(d.Year, time.January, 4, 0, 0, 0, 0, location)
+	weekday := int(jan4.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+	return time.Date(
+		d.Year,
+		time.January,
+		4-weekday+(d.Month-1)*7+d.Day,
+		0, 0, 0, 0,
+		location,
+	)
+}
+
+// Format describes an ISO week date, such as "2023-W12-3".
+func (ISO) Format(d Date) string {
+	return fmt.Sprintf("%04d-W%02d-%d", d.Year, d.Month, d.Day)
+}
@@ -0,0 +1,92 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package system
+
+import (
+	"fmt"
+	"time"
+)
+
+// Julian is the Julian calendar, as still used by some Orthodox churches.
+type Julian struct{}
+
+// julianEpoch is the fixed day of 1 January 1 in the Julian calendar.
+const julianEpoch = -1
+
+// Name of the calendar system.
+func (Julian) Name() string {
+	return "julian"
+}
+
+// Limits returns the largest month and day of a month any year may have.
+func (Julian) Limits() (int, int) {
+	return 12, 31
+}
+
+// julianLeap reports if a Julian year is a leap year. There is no year 0, so
+// year -1 is a leap year.
+func julianLeap(year int) bool {
+	if year > 0 {
+		return mod(year, 4) == 0
+	}
+	return mod(year, 4) == 3
+}
+
+// fixedFromJulian returns the fixed day of a Julian date.
+func fixedFromJulian(d Date) int {
+	y := d.Year
+	if y < 0 {
+		y++
+	}
+	day := julianEpoch - 1 +
+		365*(y-1) +
+		floorDiv(y-1, 4) +
+		floorDiv(367*d.Month-362, 12) +
+		d.Day
+	switch {
+	case d.Month <= 2:
+	case julianLeap(d.Year):
+		day--
+	default:
+		day -= 2
+	}
+	return day
+}
+
+// julianFromFixed returns the Julian date of a fixed day.
+func julianFromFixed(day int) Date {
+	approx := floorDiv(4*(day-julianEpoch)+1464, 1461)
+	year := approx
+	if approx <= 0 {
+		year--
+	}
+	prior := day - fixedFromJulian(Date{Year: year, Month: 1, Day: 1})
+	correction := 0
+	if day >= fixedFromJulian(Date{Year: year, Month: 3, Day: 1}) {
+		correction = 2
+		if julianLeap(year) {
+			correction = 1
+		}
+	}
+	month := floorDiv(12*(prior+correction)+373, 367)
+	return Date{
+		Year:  year,
+		Month: month,
+		Day:   day - fixedFromJulian(Date{Year: year, Month: month, Day: 1}) + 1,
+	}
+}
+
+// FromTime converts the day of time t to a Julian date.
+func (Julian) FromTime(t time.Time) Date {
+	return julianFromFixed(fixed(t))
+}
+
+// ToTime converts a Julian date to midnight on that day.
+func (Julian) ToTime(d Date, location *time.Location) time.Time {
+	return fromFixed(fixedFromJulian(d), location)
+}
+
+// Format describes a Julian date, such as "25 December 2022".
+func (Julian) Format(d Date) string {
+	return fmt.Sprintf("%v %v %v", d.Day, time.Month(d.Month), d.Year)
+}
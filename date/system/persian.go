@@ -0,0 +1,86 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package system
+
+import (
+	"fmt"
+	"time"
+)
+
+// Persian is the Solar Hijri calendar used in Iran and Afghanistan. Leap years
+// follow the 33 year arithmetic cycle, which agrees with the astronomical
+// calendar from 1178 to 1633 AP.
+type Persian struct{}
+
+// persianEpoch is the fixed day of 1 Farvardin 1 AP. Counting the 33 year
+// cycle back to the first year places it a day before the historical epoch of
+// 19 March 622 in the Julian calendar.
+const persianEpoch = 226895
+
+var persianMonths = [12]string{
+	"Farvardin", "Ordibehesht", "Khordad", "Tir", "Mordad", "Shahrivar",
+	"Mehr", "Aban", "Azar", "Dey", "Bahman", "Esfand",
+}
+
+// Name of the calendar system.
+func (Persian) Name() string {
+	return "persian"
+}
+
+// Limits returns the largest month and day of a month any year may have.
+func (Persian) Limits() (int, int) {
+	return 12, 31
+}
+
+// fixedFromPersian returns the fixed day of a Persian date. The first six
+// months have 31 days, the next five 30, and Esfand 29 or 30 in a leap year.
+func fixedFromPersian(d Date) int {
+	day := persianEpoch - 1 +
+		365*(d.Year-1) +
+		floorDiv(8*d.Year+21, 33) +
+		d.Day
+	if d.Month <= 7 {
+		day += 31 * (d.Month - 1)
+	} else {
+		day += 30*(d.Month-1) + 6
+	}
+	return day
+}
+
+// persianFromFixed returns the Persian date of a fixed day.
+func persianFromFixed(day int) Date {
+	year := floorDiv(33*(day-persianEpoch)+3, 12053) + 1
+	for fixedFromPersian(Date{Year: year + 1, Month: 1, Day: 1}) <= day {
+		year++
+	}
+	for fixedFromPersian(Date{Year: year, Month: 1, Day: 1}) > day {
+		year--
+	}
+	dayOfYear := day - fixedFromPersian(Date{Year: year, Month: 1, Day: 1}) + 1
+	var month int
+	if dayOfYear <= 186 {
+		month = (dayOfYear + 30) / 31
+	} else {
+		month = (dayOfYear - 6 + 29) / 30
+	}
+	return Date{
+		Year:  year,
+		Month: month,
+		Day:   day - fixedFromPersian(Date{Year: year, Month: month, Day: 1}) + 1,
+	}
+}
+
+// FromTime converts the day of time t to a Persian date.
+func (Persian) FromTime(t time.Time) Date {
+	return persianFromFixed(fixed(t))
+}
+
+// ToTime converts a Persian date to midnight on that day.
+func (Persian) ToTime(d Date, location *time.Location) time.Time {
+	return fromFixed(fixedFromPersian(d), location)
+}
+
+// Format describes a Persian date, such as "21 Aban 1324 AP".
+func (Persian) Format(d Date) string {
+	return fmt.Sprintf("%v %v %v AP", d.Day, persianMonths[d.Month-1], d.Year)
+}
@@ -0,0 +1,86 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+
+// Package system converts days between the Gregorian calendar and other
+// calendar systems.
+//
+// Conversions count days from a fixed epoch, 1 January of year 1 in the
+// proleptic Gregorian calendar, and follow the arithmetic rules described in
+// Calendrical Calculations by Reingold and Dershowitz.
+package system
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Date is a day in a calendar system. Months and days count from 1.
+type Date struct {
+	Year  int
+	Month int
+	Day   int
+}
+
+// String formats the date as a zero padded year-month-day.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// System is a calendar system.
+type System interface {
+	// Name of the calendar system, as used in the config and holiday lists.
+	Name() string
+	// FromTime converts the day of time t to a date in this calendar system.
+	FromTime(t time.Time) Date
+	// ToTime converts a date in this calendar system to midnight on that day
+	// in the given location.
+	ToTime(d Date, location *time.Location) time.Time
+	// Format describes a date in long form, such as "7 Kislev 5706".
+	Format(d Date) string
+	// Limits returns the largest month and day of a month any year may have.
+	Limits() (months, days int)
+}
+
+// systems are the supported calendar systems.
+var systems = []System{ISO{}, Julian{}, Hebrew{}, Islamic{}, Persian{}}
+
+// Get returns the calendar system with a given name, ignoring case.
+func Get(name string) (System, bool) {
+	for _, s := range systems {
+		if strings.EqualFold(s.Name(), name) {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// unixEpoch is the fixed day number of 1970-01-01.
+const unixEpoch = 719163
+
+// fixed returns the fixed day number of the day of time t, where 1 January of
+// year 1 is day 1.
+func fixed(t time.Time) int {
+	u := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return floorDiv(int(u.Unix()), 24*60*60) + unixEpoch
+}
+
+// fromFixed returns midnight on a fixed day number in the given location.
+func fromFixed(day int, location *time.Location) time.Time {
+	u := time.Unix(int64(day-unixEpoch)*24*60*60, 0).UTC()
+	return time.Date(u.Year(), u.Month(), u.Day(), 0, 0, 0, 0, location)
+}
+
+// floorDiv divides rounding towards negative infinity.
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+// mod returns the remainder of floorDiv, which always has the sign of b.
+func mod(a, b int) int {
+	return a - b*floorDiv(a, b)
+}
@@ -0,0 +1,163 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package system
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConvert(t *testing.T) {
+	type test struct {
+		system string
+		time   time.Time
+		want   Date
+	}
+
+	tests := []test{
+		{
+			system: "iso",
+			time:   time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+			want:   Date{Year: 2022, Month: 52, Day: 7},
+		},
+		{
+			system: "iso",
+			time:   time.Date(2023, time.March, 22, 0, 0, 0, 0, time.UTC),
+			want:   Date{Year: 2023, Month: 12, Day: 3},
+		},
+		{
+			system: "julian",
+			time:   time.Date(1945, time.November, 12, 0, 0, 0, 0, time.UTC),
+			want:   Date{Year: 1945, Month: 10, Day: 30},
+		},
+		{
+			// Orthodox Christmas.
+			system: "julian",
+			time:   time.Date(2023, time.January, 7, 0, 0, 0, 0, time.UTC),
+			want:   Date{Year: 2022, Month: 12, Day: 25},
+		},
+		{
+			system: "hebrew",
+			time:   time.Date(1945, time.November, 12, 0, 0, 0, 0, time.UTC),
+			want:   Date{Year: 5706, Month: 9, Day: 7},
+		},
+		{
+			// Rosh Hashanah.
+			system: "hebrew",
+			time:   time.Date(2023, time.September, 16, 0, 0, 0, 0, time.UTC),
+			want:   Date{Year: 5784, Month: 7, Day: 1},
+		},
+		{
+			// Passover.
+			system: "hebrew",
+			time:   time.Date(2023, time.April, 6, 0, 0, 0, 0, time.UTC),
+			want:   Date{Year: 5783, Month: 1, Day: 15},
+		},
+		{
+			// Purim in a leap year falls in Adar II.
+			system: "hebrew",
+			time:   time.Date(2024, time.March, 24, 0, 0, 0, 0, time.UTC),
+			want:   Date{Year: 5784, Month: 13, Day: 14},
+		},
+		{
+			system: "islamic",
+			time:   time.Date(1945, time.November, 12, 0, 0, 0, 0, time.UTC),
+			want:   Date{Year: 1364, Month: 12, Day: 6},
+		},
+		{
+			system: "persian",
+			time:   time.Date(2023, time.March, 21, 0, 0, 0, 0, time.UTC),
+			want:   Date{Year: 1402, Month: 1, Day: 1},
+		},
+		{
+			system: "persian",
+			time:   time.Date(2023, time.October, 23, 0, 0, 0, 0, time.UTC),
+			want:   Date{Year: 1402, Month: 8, Day: 1},
+		},
+		{
+			// Nowruz.
+			system: "persian",
+			time:   time.Date(2024, time.March, 20, 0, 0, 0, 0, time.UTC),
+			want:   Date{Year: 1403, Month: 1, Day: 1},
+		},
+		{
+			// The last day of the leap year 1403.
+			system: "persian",
+			time:   time.Date(2025, time.March, 20, 0, 0, 0, 0, time.UTC),
+			want:   Date{Year: 1403, Month: 12, Day: 30},
+		},
+	}
+
+	for _, tc := range tests {
+		s, ok := Get(tc.system)
+		if !ok {
+			t.Fatalf("missing system %v", tc.system)
+		}
+		got := s.FromTime(tc.time)
+		if got != tc.want {
+			t.Fatalf(
+				"%v %v: want: %v, got: %v",
+				tc.system,
+				tc.time.Format("2006-01-02"),
+				tc.want,
+				got,
+			)
+		}
+		back := s.ToTime(got, time.UTC)
+		if !back.Equal(tc.time) {
+			t.Fatalf("%v %v: converted back to %v", tc.system, got, back)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	auckland, err := time.LoadLocation("Pacific/Auckland")
+	if err != nil {
+		t.Fatalf("failed loading Pacific/Auckland timezone: %v", err)
+	}
+	start := time.Date(1990, time.January, 1, 0, 0, 0, 0, auckland)
+	for _, s := range systems {
+		for i := 0; i < 365*50; i++ {
+			day := start.AddDate(0, 0, i)
+			back := s.ToTime(s.FromTime(day), auckland)
+			if !back.Equal(day) {
+				t.Fatalf(
+					"%v: %v converted to %v and back to %v",
+					s.Name(),
+					day.Format("2006-01-02"),
+					s.FromTime(day),
+					back.Format("2006-01-02"),
+				)
+			}
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	type test struct {
+		system string
+		date   Date
+		want   string
+	}
+
+	tests := []test{
+		{system: "iso", date: Date{1945, 46, 1}, want: "1945-W46-1"},
+		{system: "julian", date: Date{1945, 10, 30}, want: "30 October 1945"},
+		{system: "hebrew", date: Date{5706, 9, 7}, want: "7 Kislev 5706"},
+		{system: "hebrew", date: Date{5784, 12, 1}, want: "1 Adar I 5784"},
+		{system: "hebrew", date: Date{5783, 12, 1}, want: "1 Adar 5783"},
+		{
+			system: "islamic",
+			date:   Date{1364, 12, 6},
+			want:   "6 Dhu al-Hijjah 1364 AH",
+		},
+		{system: "persian", date: Date{1402, 8, 1}, want: "1 Aban 1402 AP"},
+	}
+
+	for _, tc := range tests {
+		s, _ := Get(tc.system)
+		if got := s.Format(tc.date); got != tc.want {
+			t.Fatalf("want: %q, got: %q", tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package help
+
+// catalog holds translations of Content by language code. Languages which are
+// missing use Content.
+var catalog = map[string]string{
+	"de": `
+
+Auswählen                  = hjkl, Pfeiltasten oder Maus                   
+Vorschau fokussieren       = tab                                           
+Vorschau umschalten        = p                                             
+Vorschau scrollen          = jk, hoch/runter (wenn fokussiert) oder Mausrad
+Notiz bearbeiten           = enter oder Doppelklick                        
+Datum kopieren             = y                                             
+Kopieren als...            = Y                                             
+Letzter Sonntag            = b, H                                          
+Nächster Sonntag           = w                                             
+Nächster Samstag           = e, L                                          
+Einen Monat zurück         = ctrl+u                                        
+Einen Monat vor            = ctrl+d                                        
+Zu Monat wechseln          = Monatsnamen anklicken                         
+Nächster Werktag           = n                                             
+Letzter Werktag            = N                                             
+Gehe zu Datum              = g                                             
+Marke setzen               = m, dann ein Buchstabe                         
+Gehe zu Marke              = ', dann ein Buchstabe                         
+Zurückspringen             = ctrl+o                                        
+Vorspringen                = ctrl+n                                        
+Nächste/letzte Notiz       = }, {                                          
+Nächster/letzter Feiertag  = ], [                                          
+Nächstes/letztes Stichwort = ), (                                          
+Notizen durchsuchen        = /                                             
+Feiertag hinzufügen        = a                                             
+Feiertagsliste bearbeiten  = A                                             
+Zeitraum auswählen         = v oder ziehen, esc zum Beenden                
+Zeitraum anzeigen          = o (im Zeitraum)                               
+Zeitraum exportieren       = x (im Zeitraum)                               
+`,
+	"es": `
+
+Seleccionar              = hjkl, flechas o ratón                 
+Enfocar vista previa     = tab                                   
+Mostrar vista previa     = p                                     
+Desplazar vista previa   = jk, arriba/abajo (si enfocada) o rueda
+Editar nota              = enter o doble clic                    
+Copiar fecha             = y                                     
+Copiar como...           = Y                                     
+Domingo anterior         = b, H                                  
+Domingo siguiente        = w                                     
+Sábado siguiente         = e, L                                  
+Subir un mes             = ctrl+u                                
+Bajar un mes             = ctrl+d                                
+Ir a un mes              = clic en su nombre                     
+Día hábil siguiente      = n                                     
+Día hábil anterior       = N                                     
+Ir a una fecha           = g                                     
+Poner marca              = m y una letra                         
+Ir a marca               = ' y una letra                         
+Saltar atrás             = ctrl+o                                
+Saltar adelante          = ctrl+n                                
+Nota sig./ant.           = }, {                                  
+Festivo sig./ant.        = ], [                                  
+Palabra clave sig./ant.  = ), (                                  
+Buscar en notas          = /                                     
+Añadir festivo           = a                                     
+Editar lista de festivos = A                                     
+Seleccionar un rango     = v o arrastrar, esc para terminar      
+Ver notas del rango      = o (en un rango)                       
+Exportar notas del rango = x (en un rango)                       
+`,
+	"fr": `
+
+Sélectionner         = hjkl, flèches ou souris           
+Activer l’aperçu     = tab                               
+Afficher l’aperçu    = p                                 
+Défiler l’aperçu     = jk, haut/bas (si actif) ou molette
+Modifier la note     = enter ou double clic              
+Copier la date       = y                                 
+Copier comme...      = Y                                 
+Dimanche précédent   = b, H                              
+Dimanche suivant     = w                                 
+Samedi suivant       = e, L                              
+Mois précédent       = ctrl+u                            
+Mois suivant         = ctrl+d                            
+Aller à un mois      = clic sur son nom                  
+Jour ouvré suivant   = n                                 
+Jour ouvré précédent = N                                 
+Aller à une date     = g                                 
+Poser une marque     = m puis une lettre                 
+Aller à une marque   = ' puis une lettre                 
+Saut arrière         = ctrl+o                            
+Saut avant           = ctrl+n                            
+Note suiv./préc.     = }, {                              
+Férié suiv./préc.    = ], [                              
+Mot-clé suiv./préc.  = ), (                              
+Chercher les notes   = /                                 
+Ajouter un férié     = a                                 
+Modifier les fériés  = A                                 
+Choisir une période  = v ou glisser, esc pour finir      
+Voir la période      = o (dans une période)              
+Exporter la période  = x (dans une période)              
+`,
+}
@@ -8,27 +8,51 @@ import (
 
 const Content = `
 
-Select             = hjkl, arrow keys, or mouse
-Focus preview      = tab                       
-Toggle preview     = p                         
-Scroll preview     = jk, up/down (if focused)  
-Edit note          = enter                     
-Copy date          = y                         
-Goto last Sunday   = b, H                      
-Goto next Sunday   = w                         
-Goto next Saturday = e, L                      
-Go up one month    = ctrl+u                    
-Go down one month  = ctrl+d                    
+Select             = hjkl, arrow keys, or mouse        
+Focus preview      = tab                               
+Toggle preview     = p                                 
+Scroll preview     = jk, up/down (if focused), or wheel
+Edit note          = enter or double click             
+Copy date          = y                                 
+Copy as...         = Y                                 
+Goto last Sunday   = b, H                              
+Goto next Sunday   = w                                 
+Goto next Saturday = e, L                              
+Go up one month    = ctrl+u                            
+Go down one month  = ctrl+d                            
+Go to month        = click its name                    
+Next business day  = n                                 
+Last business day  = N                                 
+Go to date         = g                                 
+Set mark           = m then a letter                   
+Go to mark         = ' then a letter                   
+Jump back          = ctrl+o                            
+Jump forward       = ctrl+n                            
+Next/last note     = }, {                              
+Next/last holiday  = ], [                              
+Next/last keyword  = ), (                              
+Search notes       = /                                 
+Add holiday        = a                                 
+Edit holiday list  = A                                 
+Select a range     = v or drag, esc to stop            
+View range notes   = o (in a range)                    
+Export range notes = x (in a range)                    
 `
 
 // Help is the Bubble Tea model for this help element.
 type Help struct {
 	version string
+	content string
 }
 
-// New creates a new help model.
-func New(version string) Help {
-	return Help{version: version}
+// New creates a new help model. The help text is translated to the given
+// language code if a translation exists.
+func New(version, language string) Help {
+	content, ok := catalog[language]
+	if !ok {
+		content = Content
+	}
+	return Help{version: version, content: content}
 }
 
 // Init the help window in Bubble Tea.
@@ -43,5 +67,5 @@ func (h Help) Update(msg tea.Msg) (Help, tea.Cmd) {
 
 // View renders the preview in its current state.
 func (h Help) View() string {
-	return "Calendar " + h.version + Content
+	return "Calendar " + h.version + h.content
 }
@@ -0,0 +1,138 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package holiday
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"git.sr.ht/~kota/calendar/astro"
+)
+
+// BuiltinPrefix starts the name of a holiday list which is generated rather
+// than read from a file, such as "builtin:seasons". The name may be followed
+// by a colon and the color to use, such as "builtin:seasons:5".
+const BuiltinPrefix = "builtin:"
+
+// builtinColor is the color of builtin holidays when none is given.
+const builtinColor = "3"
+
+// builtinYears is how many years before and after the current year builtin
+// holidays are generated for.
+const builtinYears = 30
+
+// generator creates the holidays of a builtin list in a year. Times are in the
+// given location.
+type generator func(year int, location *time.Location) Holidays
+
+// builtins are the builtin holiday lists by name.
+var builtins = map[string]generator{
+	"seasons": seasons,
+	"dst":     clockChanges,
+	"nz":      public(nz),
+	"us":      public(us),
+	"gb":      public(gb),
+	"de":      public(de),
+	"de-by":   public(deBY),
+}
+
+// builtin generates the holidays for a builtin list name, without the prefix,
+// around the year of now. Times are in the location of now.
+func builtin(name string, now time.Time) (Holidays, error) {
+	location := now.Location()
+	name, color, ok := strings.Cut(name, ":")
+	if !ok {
+		color = builtinColor
+	}
+	gen, ok := builtins[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown builtin holiday list %v", name)
+	}
+
+	var holidays Holidays
+	year := now.Year()
+	for y := year - builtinYears; y <= year+builtinYears; y++ {
+		for _, h := range gen(y, location) {
+			h.Color = color
+			holidays = append(holidays, h)
+		}
+	}
+	return holidays, nil
+}
+
+// seasons generates the equinoxes and solstices.
+func seasons(year int, location *time.Location) Holidays {
+	var holidays Holidays
+	for i, t := range astro.Seasons(year) {
+		t = t.In(location)
+		holidays = append(holidays, Holiday{
+			Date:    t.Format("2006-01-02"),
+			Message: fmt.Sprintf("%v at %v", astro.SeasonNames[i], t.Format("15:04")),
+		})
+	}
+	return holidays
+}
+
+// clockChanges generates the days the clocks change for daylight saving time.
+func clockChanges(year int, location *time.Location) Holidays {
+	var holidays Holidays
+	day := time.Date(year, time.January, 1, 0, 0, 0, 0, location)
+	_, offset := day.Zone()
+	for day.Year() == year {
+		next := day.AddDate(0, 0, 1)
+		_, nextOffset := next.Zone()
+		if nextOffset == offset {
+			day = next
+			continue
+		}
+
+		// The change happened before the start of the next day. Search
+		// for the first second using the new offset.
+		lo, hi := day.Unix(), next.Unix()
+		for lo < hi {
+			mid := lo + (hi-lo)/2
+			if _, o := time.Unix(mid, 0).In(location).Zone(); o == nextOffset {
+				hi = mid
+			} else {
+				lo = mid + 1
+			}
+		}
+		// Times are described by the clock before it changes, such as
+		// "at 02:00" rather than "at 03:00".
+		change := time.Unix(lo, 0).In(location)
+		name, _ := change.Zone()
+
+		direction := "forward"
+		diff := nextOffset - offset
+		if diff < 0 {
+			direction = "back"
+			diff = -diff
+		}
+		holidays = append(holidays, Holiday{
+			Date: change.Format("2006-01-02"),
+			Message: fmt.Sprintf(
+				"Clocks go %v %v at %v (%v)",
+				direction,
+				duration(diff),
+				change.In(time.FixedZone("", offset)).Format("15:04"),
+				name,
+			),
+		})
+		offset = nextOffset
+		day = next
+	}
+	return holidays
+}
+
+// duration describes a number of seconds in hours, or minutes if it is not a
+// whole number of hours.
+func duration(seconds int) string {
+	if seconds%3600 != 0 {
+		return fmt.Sprintf("%v minutes", seconds/60)
+	}
+	if seconds == 3600 {
+		return "1 hour"
+	}
+	return fmt.Sprintf("%v hours", seconds/3600)
+}
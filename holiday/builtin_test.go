@@ -0,0 +1,110 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package holiday
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClockChanges(t *testing.T) {
+	auckland, err := time.LoadLocation("Pacific/Auckland")
+	if err != nil {
+		t.Fatalf("failed loading Pacific/Auckland timezone: %v", err)
+	}
+
+	got := clockChanges(2023, auckland)
+	want := Holidays{
+		{Date: "2023-04-02", Message: "Clocks go back 1 hour at 03:00 (NZST)"},
+		{Date: "2023-09-24", Message: "Clocks go forward 1 hour at 02:00 (NZDT)"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("want: %v, got: %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("want: %v, got: %v", want[i], got[i])
+		}
+	}
+
+	if got := clockChanges(2023, time.UTC); len(got) != 0 {
+		t.Fatalf("expected no clock changes in UTC, got: %v", got)
+	}
+}
+
+func TestBuiltin(t *testing.T) {
+	now := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	hs, err := builtin("seasons:5", now)
+	if err != nil {
+		t.Fatalf("failed generating seasons: %v", err)
+	}
+	h, ok := hs.Match(time.Date(2023, time.June, 21, 0, 0, 0, 0, time.UTC))
+	if !ok {
+		t.Fatalf("expected a match on the June solstice")
+	}
+	if want := "June solstice at 14:58"; h.Message != want || h.Color != "5" {
+		t.Fatalf("want: %q in color 5, got: %q in color %v", want, h.Message, h.Color)
+	}
+
+	if _, err := builtin("nope", now); err == nil {
+		t.Fatalf("expected an error for an unknown builtin list")
+	}
+}
+
+func TestPublic(t *testing.T) {
+	type test struct {
+		list string
+		date string
+		want string
+	}
+
+	tests := []test{
+		// Mondayised New Year's holidays on a weekend.
+		{list: "nz", date: "2022-01-03", want: "New Year's Day (observed)"},
+		{list: "nz", date: "2022-01-04", want: "Day after New Year's Day (observed)"},
+		// Christmas on a Sunday is observed after Boxing Day.
+		{list: "nz", date: "2022-12-26", want: "Boxing Day"},
+		{list: "nz", date: "2022-12-27", want: "Christmas Day (observed)"},
+		{list: "nz", date: "2022-02-07", want: "Waitangi Day (observed)"},
+		{list: "nz", date: "2023-06-05", want: "King's Birthday"},
+		{list: "nz", date: "2023-10-23", want: "Labour Day"},
+		{list: "nz", date: "2024-03-29", want: "Good Friday"},
+		{list: "nz", date: "2022-06-24", want: "Matariki"},
+		{list: "nz", date: "2052-06-21", want: "Matariki"},
+		// New Year's Day on a Saturday is observed the Friday before.
+		{list: "us", date: "2021-12-31", want: "New Year's Day (observed)"},
+		{list: "us", date: "2023-01-16", want: "Martin Luther King Jr. Day"},
+		{list: "us", date: "2023-05-29", want: "Memorial Day"},
+		{list: "us", date: "2023-11-23", want: "Thanksgiving Day"},
+		{list: "us", date: "2021-07-05", want: "Independence Day (observed)"},
+		{list: "gb", date: "2023-08-28", want: "Summer bank holiday"},
+		{list: "gb", date: "2021-12-28", want: "Boxing Day (observed)"},
+		{list: "de", date: "2023-05-18", want: "Christi Himmelfahrt"},
+		{list: "de-by", date: "2023-06-08", want: "Fronleichnam"},
+		{list: "de-by", date: "2023-11-01", want: "Allerheiligen"},
+	}
+
+	for _, tc := range tests {
+		day, err := time.Parse("2006-01-02", tc.date)
+		if err != nil {
+			t.Fatalf("failed parsing date: %v", err)
+		}
+		var got string
+		for _, h := range builtins[tc.list](day.Year(), time.UTC) {
+			if h.Date == tc.date {
+				got = h.Message
+			}
+		}
+		if got == "" {
+			// Observed days may be generated from the next year.
+			for _, h := range builtins[tc.list](day.Year()+1, time.UTC) {
+				if h.Date == tc.date {
+					got = h.Message
+				}
+			}
+		}
+		if got != tc.want {
+			t.Fatalf("%v %v: want: %q, got: %q", tc.list, tc.date, tc.want, got)
+		}
+	}
+}
@@ -2,101 +2,714 @@ package holiday
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
+
+	"git.sr.ht/~kota/calendar/clock"
+	"git.sr.ht/~kota/calendar/date"
+	"git.sr.ht/~kota/calendar/date/system"
+	"github.com/charmbracelet/lipgloss"
 )
 
 type Holidays []Holiday
 
 // Match attempts to match a given time with a holiday.
 func (hs Holidays) Match(t time.Time) (Holiday, bool) {
+	d := t.Format("2006-01-02")
 	for _, h := range hs {
-		if h.Date == t.Format("2006-01-02") {
-			return h, true
+		if h.matches(t, d) {
+			return h.On(t), true
 		}
-		if strings.TrimPrefix(h.Date, "0000-") == t.Format("01-02") {
-			return h, true
+	}
+	return Holiday{}, false
+}
+
+// MatchAll returns every holiday matching a given time, in order of
+// precedence.
+func (hs Holidays) MatchAll(t time.Time) Holidays {
+	d := t.Format("2006-01-02")
+	var matched Holidays
+	for _, h := range hs {
+		if h.matches(t, d) {
+			matched = append(matched, h.On(t))
 		}
-		if strings.TrimPrefix(h.Date, "0000-00-") == t.Format("02") {
-			return h, true
+	}
+	return matched
+}
+
+// Messages returns the messages of the holidays, leaving out empty ones.
+func (hs Holidays) Messages() []string {
+	var messages []string
+	for _, h := range hs {
+		if h.Message != "" {
+			messages = append(messages, h.Message)
 		}
 	}
-	return Holiday{}, false
+	return messages
 }
 
-// Prefix a note if the holiday matches a given date.
+// Prefix a note with the message of every holiday matching a given date, each
+// in its own paragraph and style.
 func (hs Holidays) Prefix(t time.Time, note string) string {
-	if h, ok := hs.Match(t); ok {
-		note = h.Message + "\n\n" + note
+	var prefix string
+	for _, h := range hs.MatchAll(t) {
+		if h.Message != "" {
+			prefix += h.Style().Render(h.Message) + "\n\n"
+		}
+	}
+	return prefix + note
+}
+
+// Style returns the lipgloss style a holiday is displayed with.
+func (h Holiday) Style() lipgloss.Style {
+	style := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(h.Color)).
+		Bold(h.Bold).
+		Italic(h.Italic)
+	if h.Background != "" {
+		style = style.Background(lipgloss.Color(h.Background))
+	}
+	return style
+}
+
+// DayOff reports if a given time matches a holiday which is a day off.
+func (hs Holidays) DayOff(t time.Time) bool {
+	d := t.Format("2006-01-02")
+	for _, h := range hs {
+		if h.DayOff && h.matches(t, d) {
+			return true
+		}
 	}
-	return note
+	return false
 }
 
+// Next returns the first day on or after time t which the holiday falls on.
+func (h Holiday) Next(t time.Time) (time.Time, bool) {
+	for i := 0; i < searchDays; i++ {
+		day := time.Date(
+			t.Year(),
+			t.Month(),
+			t.Day()+i,
+			0, 0, 0, 0,
+			t.Location(),
+		)
+		if h.matches(day, day.Format("2006-01-02")) {
+			return day, true
+		}
+	}
+	return t, false
+}
+
+// searchDays is how many days Find and Next search before giving up.
+const searchDays = 3660
+
+// Find returns the nearest day after time t which matches a holiday. If step
+// is negative the nearest day before time t is returned instead.
+func (hs Holidays) Find(t time.Time, step int) (time.Time, bool) {
+	if len(hs) == 0 {
+		return t, false
+	}
+	if step < 0 {
+		step = -1
+	} else {
+		step = 1
+	}
+
+	for i := 1; i <= searchDays; i++ {
+		day := time.Date(
+			t.Year(),
+			t.Month(),
+			t.Day()+i*step,
+			0, 0, 0, 0,
+			t.Location(),
+		)
+		if _, ok := hs.Match(day); ok {
+			return day, true
+		}
+	}
+	return t, false
+}
+
+// Kind is the type of a holiday.
+type Kind uint8
+
+const (
+	// KindHoliday is a plain holiday, shown with its message.
+	KindHoliday Kind = iota
+	// KindBirthday is a yearly birthday whose message is the person's name.
+	KindBirthday
+	// KindAnniversary is a yearly anniversary of the event in its message.
+	KindAnniversary
+)
+
 type Holiday struct {
-	Date    string
+	Date string
+	// End is the last date of a holiday covering a range of days, written in
+	// the same format as Date. It is empty for a single day.
+	End     string
 	Color   string
 	Message string
+	// System is the calendar system Date is written in. Nil is the Gregorian
+	// calendar.
+	System system.System
+	Kind   Kind
+	// Year a birthday or anniversary started, used to count how many years
+	// have passed.
+	Year int
+	// Fallback is the month and day, such as "02-28", a birthday or
+	// anniversary on the 29th of February falls on in other years.
+	Fallback   string
+	Background string
+	Bold       bool
+	Italic     bool
+	// List is the name given to the holiday's list by its @name header, if
+	// any.
+	List      string
+	DayOff    bool
+	Countdown bool
 }
 
-func Load(lists []string) Holidays {
-	var holidays []Holiday
+// On returns the holiday as it falls on time t. Birthdays and anniversaries
+// have their message written with the number of years since they started,
+// such as "Alice's 35th birthday".
+func (h Holiday) On(t time.Time) Holiday {
+	years := h.year(t) - h.Year
+	switch {
+	case h.Kind == KindBirthday && h.Year == 0:
+		h.Message = h.Message + "'s birthday"
+	case h.Kind == KindAnniversary && h.Year == 0:
+	case h.Kind == KindBirthday && years == 0:
+		h.Message = h.Message + " was born"
+	case h.Kind == KindBirthday:
+		h.Message = fmt.Sprintf("%v's %v birthday", h.Message, ordinal(years))
+	case h.Kind == KindAnniversary && years > 0:
+		h.Message = fmt.Sprintf("%v (%v anniversary)", h.Message, ordinal(years))
+	}
+	return h
+}
+
+// year returns the year of time t in the holiday's calendar system.
+func (h Holiday) year(t time.Time) int {
+	if h.System != nil {
+		return h.System.FromTime(t).Year
+	}
+	return t.Year()
+}
+
+// ordinal writes a number as an ordinal such as 1st, 2nd, 3rd, or 11th.
+func ordinal(n int) string {
+	suffix := "th"
+	switch n % 10 {
+	case 1:
+		suffix = "st"
+	case 2:
+		suffix = "nd"
+	case 3:
+		suffix = "rd"
+	}
+	if n%100 >= 11 && n%100 <= 13 {
+		suffix = "th"
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+// matches reports if the holiday falls on the day of time t, which is also
+// given formatted as 2006-01-02.
+func (h Holiday) matches(t time.Time, d string) bool {
+	if h.System != nil {
+		d = h.System.FromTime(t).String()
+	}
+	if h.Kind != KindHoliday {
+		if h.year(t) < h.Year {
+			return false
+		}
+		if h.Fallback == d[5:] && date.DaysIn(time.February, t.Year()) == 28 {
+			return true
+		}
+	}
+	if h.End != "" {
+		return h.covers(d)
+	}
+	if h.Date == d {
+		return true
+	}
+	if strings.TrimPrefix(h.Date, "0000-") == d[5:] {
+		return true
+	}
+	if strings.TrimPrefix(h.Date, "0000-00-") == d[8:] {
+		return true
+	}
+	return false
+}
+
+// covers reports if a holiday range covers a given date, formatted as
+// 2006-01-02.
+func (h Holiday) covers(d string) bool {
+	prefix := recurrence(h.Date)
+	n := len(prefix)
+	if n == 0 {
+		return d >= h.Date && d <= h.End
+	}
+	start, end, day := h.Date[n:], h.End[n:], d[n:]
+	if start <= end {
+		return day >= start && day <= end
+	}
+	// The range wraps around the end of the year or month.
+	return day >= start || day <= end
+}
+
+// Covers reports if the holiday falls on the day of time t.
+func (h Holiday) Covers(t time.Time) bool {
+	return h.matches(t, t.Format("2006-01-02"))
+}
+
+// Options describe how holiday lists are loaded. Lists are named exactly as
+// they are given to Load.
+type Options struct {
+	// DayOff lists are days off.
+	DayOff []string
+	// Countdown lists are counted down to.
+	Countdown []string
+	// Birthday lists contain birthdays, each with the year of birth.
+	Birthday []string
+	// Anniversary lists contain anniversaries, each with the year it started.
+	Anniversary []string
+	// Precedence lists come first, in the order given, followed by the other
+	// lists in the order they are given to Load. When several holidays fall on
+	// the same day the first is shown in the calendar.
+	Precedence []string
+	// LeapDayFallback is the month and day, such as "02-28", birthdays and
+	// anniversaries on the 29th of February fall on in other years. If empty
+	// they only fall on leap years.
+	LeapDayFallback string
+	// Location is used for times in builtin lists, such as the start of
+	// daylight saving time.
+	Location *time.Location
+	// Clock tells the current year, around which builtin lists are
+	// generated. If nil the system clock is used.
+	Clock clock.Clock
+}
+
+// Load reads and parses each holiday list and marks the holidays as described
+// by the options. Lists starting with BuiltinPrefix are generated instead of
+// read from a file. Lists are matched to the options by the name they are
+// given to Load or by the name in their @name header.
+//
+// Empty list names, such as the default HolidayLists, are ignored. Lines of a
+// list which fail to parse are skipped and a list which fails to load is left
+// out. Every failure is returned together in the error, along
+// with the holidays which did load.
+func Load(lists []string, opts Options) (Holidays, error) {
+	location := opts.Location
+	if location == nil {
+		location = time.Local
+	}
+	// Each list is loaded into a group which is then ordered by precedence.
+	type group struct {
+		rank     int
+		holidays Holidays
+	}
+	var groups []group
+	var errs []string
 	for _, l := range lists {
-		f, err := os.Open(os.ExpandEnv(l))
-		if err != nil {
-			log.Println(err)
+		if strings.TrimSpace(l) == "" {
 			continue
 		}
-		defer f.Close()
-
-		h, err := parse(f)
+		var h Holidays
+		var err error
+		if strings.HasPrefix(l, BuiltinPrefix) {
+			h, err = builtin(
+				strings.TrimPrefix(l, BuiltinPrefix),
+				clock.Or(opts.Clock).Now().In(location),
+			)
+		} else {
+			h, err = load(l)
+		}
 		if err != nil {
-			log.Printf("failed parsing %v: %v\n", l, err)
+			errs = append(errs, err.Error())
+		}
+		for i := range h {
+			in := func(names []string) bool {
+				return contains(names, l) ||
+					(h[i].List != "" && contains(names, h[i].List))
+			}
+			h[i].DayOff = h[i].DayOff || in(opts.DayOff)
+			h[i].Countdown = in(opts.Countdown)
+			if in(opts.Birthday) {
+				h[i] = yearly(h[i], KindBirthday, opts.LeapDayFallback)
+			} else if in(opts.Anniversary) {
+				h[i] = yearly(h[i], KindAnniversary, opts.LeapDayFallback)
+			}
+		}
+		rank := index(opts.Precedence, l)
+		if len(h) > 0 && h[0].List != "" && rank == len(opts.Precedence) {
+			rank = index(opts.Precedence, h[0].List)
 		}
-		holidays = append(holidays, h...)
+		groups = append(groups, group{rank, h})
 	}
-	return holidays
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].rank < groups[j].rank
+	})
+
+	var holidays []Holiday
+	for _, g := range groups {
+		holidays = append(holidays, g.holidays...)
+	}
+	if len(errs) > 0 {
+		return holidays, errors.New(strings.Join(errs, "; "))
+	}
+	return holidays, nil
+}
+
+// yearly makes a holiday into a birthday or anniversary which repeats every
+// year after the year in its date.
+func yearly(h Holiday, kind Kind, fallback string) Holiday {
+	h.Kind = kind
+	if year, err := strconv.Atoi(h.Date[:4]); err == nil {
+		h.Year = year
+	}
+	h.Date = "0000" + h.Date[4:]
+	if h.End != "" {
+		h.End = "0000" + h.End[4:]
+	}
+	if h.Date == "0000-02-29" && h.System == nil {
+		h.Fallback = fallback
+	}
+	return h
 }
 
+// load reads and parses a holiday list file.
+func load(path string) (Holidays, error) {
+	f, err := os.Open(os.ExpandEnv(path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h, err := parse(f)
+	if err != nil {
+		return h, fmt.Errorf("failed parsing %v: %v", path, err)
+	}
+	return h, nil
+}
+
+// index returns the position of s in a list of strings, or the length of the
+// list if it is not found.
+func index(list []string, s string) int {
+	for i, l := range list {
+		if l == s {
+			return i
+		}
+	}
+	return len(list)
+}
+
+// contains reports if a list of strings contains s.
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
+
+// LineError is a line of a holiday list which could not be parsed.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e LineError) Error() string {
+	return fmt.Sprintf("line %v: %v", e.Line, e.Err)
+}
+
+// ParseError lists every line of a holiday list which could not be parsed.
+// The other lines are still used.
+type ParseError []LineError
+
+func (e ParseError) Error() string {
+	lines := make([]string, len(e))
+	for i, l := range e {
+		lines[i] = l.Error()
+	}
+	return strings.Join(lines, "; ")
+}
+
+// parse reads a holiday list. Each line is a date, a style, and a message, or
+// a header directive starting with @ before the first holiday. Lines which
+// fail to parse are skipped and returned together in a ParseError.
 func parse(r io.Reader) ([]Holiday, error) {
 	var holidays []Holiday
+	var errs ParseError
+	// defaults holds the list's name, style, and day off flag from the
+	// header, which each holiday starts from.
+	var defaults Holiday
 	scanner := bufio.NewScanner(r)
 	for i := 1; scanner.Scan(); i++ {
-		text := scanner.Text()
-		if text == "" {
+		parts, err := fields(scanner.Text())
+		if err != nil {
+			errs = append(errs, LineError{i, err})
+			continue
+		}
+		if len(parts) == 0 {
 			continue
 		}
 
-		parts := strings.Split(text, " ")
-		if len(parts) < 2 {
-			return nil, fmt.Errorf(
-				"line %v: not enough fields",
-				i,
-			)
+		if strings.HasPrefix(parts[0], "@") {
+			if len(holidays) > 0 {
+				err = fmt.Errorf("header must come before the first holiday")
+			} else {
+				defaults, err = parseHeader(parts, defaults)
+			}
+			if err != nil {
+				errs = append(errs, LineError{i, err})
+			}
+			continue
 		}
 
-		date, err := parseDate(parts[0])
+		h, err := parseHoliday(parts, defaults)
 		if err != nil {
-			return nil, fmt.Errorf("invalid date %v: %v", parts[0], err)
+			errs = append(errs, LineError{i, err})
+			continue
+		}
+		holidays = append(holidays, h)
+	}
+	if err := scanner.Err(); err != nil {
+		return holidays, err
+	}
+	if len(errs) > 0 {
+		return holidays, errs
+	}
+	return holidays, nil
+}
+
+// parseHeader applies a header directive to the list's defaults. The
+// directives are @name, @color, @style, and @dayoff.
+func parseHeader(parts []string, defaults Holiday) (Holiday, error) {
+	value := strings.Join(parts[1:], " ")
+	switch parts[0] {
+	case "@name":
+		defaults.List = value
+	case "@color":
+		defaults.Color = value
+	case "@style":
+		return parseStyle(value, defaults)
+	case "@dayoff":
+		switch value {
+		case "", "true":
+			defaults.DayOff = true
+		case "false":
+			defaults.DayOff = false
+		default:
+			return defaults, fmt.Errorf("invalid @dayoff %v", value)
+		}
+	default:
+		return defaults, fmt.Errorf("unknown header %v", parts[0])
+	}
+	return defaults, nil
+}
+
+// parseHoliday parses the fields of a holiday line on top of the list's
+// defaults. The style may be left out entirely if the list has a default
+// color.
+func parseHoliday(parts []string, defaults Holiday) (Holiday, error) {
+	h := defaults
+	var sys system.System
+	d := parts[0]
+	if name, rest, ok := strings.Cut(d, ":"); ok {
+		sys, ok = system.Get(name)
+		if !ok {
+			return h, fmt.Errorf("unknown calendar system %v", name)
 		}
-		color := parts[1]
-		message := strings.Join(parts[2:], " ")
+		d = rest
+	}
 
-		holidays = append(holidays, Holiday{
-			Date:    date,
-			Color:   color,
-			Message: message,
-		})
+	start, end, isRange := strings.Cut(d, "..")
+	date, err := parseDay(start, sys)
+	if err != nil {
+		return h, fmt.Errorf("invalid date %v: %v", parts[0], err)
+	}
+	var last string
+	if isRange {
+		last, err = parseDay(end, sys)
+		if err != nil {
+			return h, fmt.Errorf("invalid date %v: %v", parts[0], err)
+		}
+		if err := checkRange(date, last); err != nil {
+			return h, fmt.Errorf("invalid range %v: %v", parts[0], err)
+		}
 	}
+	h.Date = date
+	h.End = last
+	h.System = sys
 
-	return holidays, scanner.Err()
+	if len(parts) > 1 {
+		// The style may be left out when the list sets a default color, so
+		// the message starts straight after the date.
+		message := parts[1:]
+		if isStyle(parts[1]) {
+			h, err = parseStyle(parts[1], h)
+			if err != nil {
+				return h, err
+			}
+			message = parts[2:]
+		}
+		h.Message = strings.Join(message, " ")
+	}
+	if h.Color == "" {
+		return h, fmt.Errorf("not enough fields")
+	}
+	return h, nil
+}
+
+// parseStyle applies a comma separated style, such as "1,bold,bg=4", to a
+// holiday. The first item is the color, which may be left empty to keep the
+// default. A style of "-" keeps every default.
+func parseStyle(s string, h Holiday) (Holiday, error) {
+	if s == "-" {
+		return h, nil
+	}
+	for i, item := range strings.Split(s, ",") {
+		switch {
+		case item == "bold":
+			h.Bold = true
+		case item == "italic":
+			h.Italic = true
+		case strings.HasPrefix(item, "bg="):
+			h.Background = strings.TrimPrefix(item, "bg=")
+		case i == 0:
+			if item != "" {
+				h.Color = item
+			}
+		default:
+			return h, fmt.Errorf("unknown style %v", item)
+		}
+	}
+	return h, nil
+}
+
+// isStyle reports if a field of a holiday is a style rather than the start of
+// its message. A style is "-" or starts with a color, an empty color before a
+// comma, bold, italic, or bg=.
+func isStyle(s string) bool {
+	if s == "-" {
+		return true
+	}
+	first, _, _ := strings.Cut(s, ",")
+	return first == "" ||
+		first == "bold" ||
+		first == "italic" ||
+		strings.HasPrefix(first, "bg=") ||
+		isColor(first)
+}
+
+// isColor reports if s is an ANSI color number, such as 5, or a hex color, such
+// as #04B575.
+func isColor(s string) bool {
+	if strings.HasPrefix(s, "#") {
+		s = s[1:]
+		if len(s) != 3 && len(s) != 6 {
+			return false
+		}
+		_, err := strconv.ParseUint(s, 16, 32)
+		return err == nil
+	}
+	_, err := strconv.ParseUint(s, 10, 8)
+	return err == nil
+}
+
+// fields splits a line of a holiday list on spaces and tabs. A field may be
+// quoted with double quotes to include spaces or a #, using a backslash to
+// escape a quote or backslash. A # on its own or followed by a space starts a
+// comment to the end of the line, as does a # at the start of the line.
+func fields(line string) ([]string, error) {
+	if strings.HasPrefix(strings.TrimSpace(line), "#") {
+		return nil, nil
+	}
+	var parts []string
+	for i := 0; i < len(line); {
+		switch c := line[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '#' && (i+1 == len(line) || isSpace(line[i+1])):
+			return parts, nil
+		case c == '"':
+			var b strings.Builder
+			i++
+			for ; i < len(line) && line[i] != '"'; i++ {
+				if line[i] == '\\' && i+1 < len(line) {
+					i++
+				}
+				b.WriteByte(line[i])
+			}
+			if i == len(line) {
+				return nil, fmt.Errorf("unterminated quote")
+			}
+			i++
+			if i < len(line) && !isSpace(line[i]) {
+				return nil, fmt.Errorf("missing space after quote")
+			}
+			parts = append(parts, b.String())
+		default:
+			start := i
+			for i < len(line) && !isSpace(line[i]) {
+				i++
+			}
+			parts = append(parts, line[start:i])
+		}
+	}
+	return parts, nil
+}
+
+// isSpace reports if a byte separates fields in a holiday list.
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
+
+// parseDay parses a date in a calendar system, or the Gregorian calendar if
+// the system is nil.
+func parseDay(s string, sys system.System) (string, error) {
+	if sys != nil {
+		return parseSystemDate(s, sys)
+	}
+	return parseDate(s)
+}
+
+// checkRange reports an error if a range of dates mixes formats, such as a
+// full date and a yearly one, or if a range of full dates ends before it
+// starts. Yearly and monthly ranges may wrap around, such as 12-24..01-02.
+func checkRange(start, end string) error {
+	if recurrence(start) != recurrence(end) {
+		return fmt.Errorf("both dates must be written in the same format")
+	}
+	if recurrence(start) == "" && end < start {
+		return fmt.Errorf("range ends before it starts")
+	}
+	return nil
+}
+
+// recurrence returns the zeroed prefix of a parsed date: "0000-00-" for
+// monthly dates, "0000-" for yearly dates, or "" for a single date.
+func recurrence(date string) string {
+	switch {
+	case strings.HasPrefix(date, "0000-00-"):
+		return "0000-00-"
+	case strings.HasPrefix(date, "0000-"):
+		return "0000-"
+	}
+	return ""
 }
 
 func parseDate(s string) (string, error) {
-	location := time.Now().Location()
+	// Only the date is kept, so the location makes no difference.
+	location := time.UTC
 	date, err := time.ParseInLocation("2006-01-02", s, location)
 	if err == nil {
 		return date.Format("2006-01-02"), nil
@@ -116,3 +729,32 @@ func parseDate(s string) (string, error) {
 	)
 	return d, err
 }
+
+// parseSystemDate parses a date in another calendar system in the same formats
+// as parseDate. Months and days are checked to be in the largest range the
+// calendar system allows in any year, such as weeks 1 to 53 in the ISO week
+// calendar.
+func parseSystemDate(s string, sys system.System) (string, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) > 3 {
+		return "", fmt.Errorf("too many fields")
+	}
+	// Missing year and month fields are zero.
+	fields := make([]int, 3-len(parts), 3)
+	for _, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return "", err
+		}
+		fields = append(fields, n)
+	}
+	year, month, day := fields[0], fields[1], fields[2]
+	months, days := sys.Limits()
+	if len(parts) > 1 && (month < 1 || month > months) {
+		return "", fmt.Errorf("month out of range")
+	}
+	if day < 1 || day > days {
+		return "", fmt.Errorf("day out of range")
+	}
+	return system.Date{Year: year, Month: month, Day: day}.String(), nil
+}
@@ -0,0 +1,470 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package holiday
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFind(t *testing.T) {
+	hs := Holidays{
+		{Date: "0000-12-25", Message: "Christmas"},
+		{Date: "2025-04-18", Message: "Good Friday"},
+	}
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	type test struct {
+		from   time.Time
+		step   int
+		want   time.Time
+		wantOK bool
+	}
+
+	tests := []test{
+		{
+			from:   day(2025, time.January, 1),
+			step:   1,
+			want:   day(2025, time.April, 18),
+			wantOK: true,
+		},
+		{
+			from:   day(2025, time.April, 18),
+			step:   1,
+			want:   day(2025, time.December, 25),
+			wantOK: true,
+		},
+		{
+			from:   day(2025, time.April, 18),
+			step:   -1,
+			want:   day(2024, time.December, 25),
+			wantOK: true,
+		},
+	}
+
+	for _, tc := range tests {
+		got, ok := hs.Find(tc.from, tc.step)
+		if ok != tc.wantOK || !got.Equal(tc.want) {
+			t.Fatalf(
+				"from: %v, step: %v, want: %v, got: %v",
+				tc.from,
+				tc.step,
+				tc.want,
+				got,
+			)
+		}
+	}
+
+	if _, ok := (Holidays{}).Find(day(2025, time.January, 1), 1); ok {
+		t.Fatalf("expected no holidays to be found")
+	}
+}
+
+func TestSystemHolidays(t *testing.T) {
+	list := strings.Join([]string{
+		"hebrew:07-01 3 Rosh Hashanah",
+		"islamic:09-01 2 Ramadan",
+		"julian:12-25 5 Orthodox Christmas",
+		"persian:1402-01-01 4 Nowruz 1402",
+		"iso:20-5 6 Week 20 Friday",
+	}, "\n")
+	hs, err := parse(strings.NewReader(list))
+	if err != nil {
+		t.Fatalf("failed parsing holidays: %v", err)
+	}
+
+	type test struct {
+		day  time.Time
+		want string
+	}
+
+	tests := []test{
+		{
+			day:  time.Date(2023, time.September, 16, 0, 0, 0, 0, time.UTC),
+			want: "Rosh Hashanah",
+		},
+		{
+			day:  time.Date(2024, time.October, 3, 0, 0, 0, 0, time.UTC),
+			want: "Rosh Hashanah",
+		},
+		{
+			day:  time.Date(2023, time.January, 7, 0, 0, 0, 0, time.UTC),
+			want: "Orthodox Christmas",
+		},
+		{
+			day:  time.Date(2023, time.March, 21, 0, 0, 0, 0, time.UTC),
+			want: "Nowruz 1402",
+		},
+		{
+			day:  time.Date(2024, time.March, 20, 0, 0, 0, 0, time.UTC),
+			want: "",
+		},
+		{
+			day:  time.Date(2023, time.May, 19, 0, 0, 0, 0, time.UTC),
+			want: "Week 20 Friday",
+		},
+	}
+
+	for _, tc := range tests {
+		h, _ := Holidays(hs).Match(tc.day)
+		if h.Message != tc.want {
+			t.Fatalf(
+				"%v: want: %q, got: %q",
+				tc.day.Format("2006-01-02"),
+				tc.want,
+				h.Message,
+			)
+		}
+	}
+
+	if _, err := parse(strings.NewReader("mayan:01-01 1 Nope")); err == nil {
+		t.Fatalf("expected an error for an unknown calendar system")
+	}
+	// Each calendar system has its own range of months and days.
+	for _, line := range []string{
+		"hebrew:14-01 1 Nope",
+		"islamic:13-01 1 Nope",
+		"iso:54-1 1 Nope",
+		"iso:20-8 1 Nope",
+	} {
+		if _, err := parse(strings.NewReader(line)); err == nil {
+			t.Fatalf("expected an error for a date out of range: %q", line)
+		}
+	}
+}
+
+func TestBirthdays(t *testing.T) {
+	dir := t.TempDir()
+	birthdays := filepath.Join(dir, "birthdays")
+	list := "1990-04-12 5 Alice\n2000-02-29 5 Leap\n04-20 5 Bob\n"
+	if err := os.WriteFile(birthdays, []byte(list), 0o644); err != nil {
+		t.Fatalf("failed writing list: %v", err)
+	}
+	anniversaries := filepath.Join(dir, "anniversaries")
+	list = "2012-11-03 5 Our wedding\n"
+	if err := os.WriteFile(anniversaries, []byte(list), 0o644); err != nil {
+		t.Fatalf("failed writing list: %v", err)
+	}
+
+	hs, err := Load([]string{birthdays, anniversaries}, Options{
+		Birthday:        []string{birthdays},
+		Anniversary:     []string{anniversaries},
+		LeapDayFallback: "03-01",
+	})
+	if err != nil {
+		t.Fatalf("failed loading lists: %v", err)
+	}
+
+	type test struct {
+		day  time.Time
+		want string
+	}
+
+	tests := []test{
+		{
+			day:  time.Date(2025, time.April, 12, 0, 0, 0, 0, time.UTC),
+			want: "Alice's 35th birthday",
+		},
+		{
+			day:  time.Date(2011, time.April, 12, 0, 0, 0, 0, time.UTC),
+			want: "Alice's 21st birthday",
+		},
+		{
+			day:  time.Date(1990, time.April, 12, 0, 0, 0, 0, time.UTC),
+			want: "Alice was born",
+		},
+		{
+			day:  time.Date(1989, time.April, 12, 0, 0, 0, 0, time.UTC),
+			want: "",
+		},
+		{
+			day:  time.Date(2025, time.April, 20, 0, 0, 0, 0, time.UTC),
+			want: "Bob's birthday",
+		},
+		{
+			day:  time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+			want: "Leap's 24th birthday",
+		},
+		{
+			day:  time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC),
+			want: "Leap's 23rd birthday",
+		},
+		{
+			day:  time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			want: "",
+		},
+		{
+			day:  time.Date(2025, time.November, 3, 0, 0, 0, 0, time.UTC),
+			want: "Our wedding (13th anniversary)",
+		},
+	}
+
+	for _, tc := range tests {
+		h, _ := hs.Match(tc.day)
+		if h.Message != tc.want {
+			t.Fatalf(
+				"%v: want: %q, got: %q",
+				tc.day.Format("2006-01-02"),
+				tc.want,
+				h.Message,
+			)
+		}
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	want := map[int]string{
+		1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th",
+		13: "13th", 21: "21st", 22: "22nd", 101: "101st", 111: "111th",
+	}
+	for n, w := range want {
+		if got := ordinal(n); got != w {
+			t.Fatalf("want: %q, got: %q", w, got)
+		}
+	}
+}
+
+func TestRanges(t *testing.T) {
+	list := strings.Join([]string{
+		"2025-12-24..2026-01-05 3 Office closed",
+		"12-24..01-02 4 Summer holidays",
+		"03-10..03-12 5 Conference",
+		"28..02 6 Month end",
+	}, "\n")
+	hs, err := parse(strings.NewReader(list))
+	if err != nil {
+		t.Fatalf("failed parsing holidays: %v", err)
+	}
+
+	type test struct {
+		day  time.Time
+		want string
+	}
+
+	tests := []test{
+		{
+			day:  time.Date(2025, time.December, 24, 0, 0, 0, 0, time.UTC),
+			want: "Office closed",
+		},
+		{
+			day:  time.Date(2026, time.January, 5, 0, 0, 0, 0, time.UTC),
+			want: "Office closed",
+		},
+		{
+			day:  time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC),
+			want: "Summer holidays",
+		},
+		{
+			day:  time.Date(2026, time.January, 2, 0, 0, 0, 0, time.UTC),
+			want: "Office closed",
+		},
+		{
+			day:  time.Date(2027, time.January, 2, 0, 0, 0, 0, time.UTC),
+			want: "Summer holidays",
+		},
+		{
+			day:  time.Date(2027, time.January, 3, 0, 0, 0, 0, time.UTC),
+			want: "",
+		},
+		{
+			day:  time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC),
+			want: "Conference",
+		},
+		{
+			day:  time.Date(2024, time.March, 13, 0, 0, 0, 0, time.UTC),
+			want: "",
+		},
+		{
+			day:  time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+			want: "Month end",
+		},
+	}
+
+	for _, tc := range tests {
+		h, _ := Holidays(hs).Match(tc.day)
+		if h.Message != tc.want {
+			t.Fatalf(
+				"%v: want: %q, got: %q",
+				tc.day.Format("2006-01-02"),
+				tc.want,
+				h.Message,
+			)
+		}
+	}
+
+	bad := []string{
+		"2026-01-05..2025-12-24 1 Backwards",
+		"2025-12-24..01-02 1 Mixed",
+	}
+	for _, line := range bad {
+		if _, err := parse(strings.NewReader(line)); err == nil {
+			t.Fatalf("expected an error parsing %q", line)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	list := strings.Join([]string{
+		"# School holidays",
+		"@name School",
+		"@color 4",
+		"@style italic,bg=8",
+		"@dayoff",
+		"",
+		"04-12 - Easter break # ends on the 26th",
+		"07-05 ,bold \"Winter # break\"",
+		"09-27 2 Spring break",
+		"10-01",
+		"13-01 1 Bad month",
+		"10-02 1 \"Unterminated",
+		"@color 5",
+		"12-20 1,underline Bad style",
+		"12-21 1 Last",
+		"12-25 Christmas Day",
+		"12-26 #5F87AF Boxing Day",
+	}, "\n")
+	hs, err := parse(strings.NewReader(list))
+	var perr ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("want a ParseError, got: %v", err)
+	}
+	var lines []int
+	for _, l := range perr {
+		lines = append(lines, l.Line)
+	}
+	if want := []int{11, 12, 13, 14}; !reflect.DeepEqual(lines, want) {
+		t.Fatalf("bad lines want: %v, got: %v", want, lines)
+	}
+
+	want := []Holiday{
+		{
+			Date:       "0000-04-12",
+			Color:      "4",
+			Message:    "Easter break",
+			Background: "8",
+			Italic:     true,
+			List:       "School",
+			DayOff:     true,
+		},
+		{
+			Date:       "0000-07-05",
+			Color:      "4",
+			Message:    "Winter # break",
+			Background: "8",
+			Bold:       true,
+			Italic:     true,
+			List:       "School",
+			DayOff:     true,
+		},
+		{
+			Date:       "0000-09-27",
+			Color:      "2",
+			Message:    "Spring break",
+			Background: "8",
+			Italic:     true,
+			List:       "School",
+			DayOff:     true,
+		},
+		{
+			Date:       "0000-10-01",
+			Color:      "4",
+			Background: "8",
+			Italic:     true,
+			List:       "School",
+			DayOff:     true,
+		},
+		{
+			Date:       "0000-12-21",
+			Color:      "1",
+			Message:    "Last",
+			Background: "8",
+			Italic:     true,
+			List:       "School",
+			DayOff:     true,
+		},
+		{
+			Date:       "0000-12-25",
+			Color:      "4",
+			Message:    "Christmas Day",
+			Background: "8",
+			Italic:     true,
+			List:       "School",
+			DayOff:     true,
+		},
+		{
+			Date:       "0000-12-26",
+			Color:      "#5F87AF",
+			Message:    "Boxing Day",
+			Background: "8",
+			Italic:     true,
+			List:       "School",
+			DayOff:     true,
+		},
+	}
+	if !reflect.DeepEqual(hs, want) {
+		t.Fatalf("want: %+v, got: %+v", want, hs)
+	}
+
+	for _, line := range []string{"10-01", "12-25 Christmas Day"} {
+		if _, err := parse(strings.NewReader(line)); err == nil {
+			t.Fatalf("expected an error for a holiday without a color: %q", line)
+		}
+	}
+}
+
+func TestMatchAll(t *testing.T) {
+	dir := t.TempDir()
+	public := filepath.Join(dir, "public")
+	if err := os.WriteFile(public, []byte("12-25 1 Christmas\n"), 0o644); err != nil {
+		t.Fatalf("failed writing list: %v", err)
+	}
+	birthdays := filepath.Join(dir, "birthdays")
+	list := "@name friends\n1990-12-25 5 Alice\n"
+	if err := os.WriteFile(birthdays, []byte(list), 0o644); err != nil {
+		t.Fatalf("failed writing list: %v", err)
+	}
+	day := time.Date(2025, time.December, 25, 0, 0, 0, 0, time.UTC)
+
+	hs, err := Load([]string{public, birthdays}, Options{
+		Birthday: []string{birthdays},
+	})
+	if err != nil {
+		t.Fatalf("failed loading lists: %v", err)
+	}
+	want := []string{"Christmas", "Alice's 35th birthday"}
+	if got := hs.MatchAll(day).Messages(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("want: %q, got: %q", want, got)
+	}
+
+	hs, err = Load([]string{public, birthdays}, Options{
+		Birthday:   []string{birthdays},
+		Precedence: []string{"friends"},
+	})
+	if err != nil {
+		t.Fatalf("failed loading lists: %v", err)
+	}
+	want = []string{"Alice's 35th birthday", "Christmas"}
+	if got := hs.MatchAll(day).Messages(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("want: %q, got: %q", want, got)
+	}
+	if h, _ := hs.Match(day); h.Color != "5" {
+		t.Fatalf("want the birthday's color to win, got: %v", h.Color)
+	}
+}
+
+func TestLoadEmpty(t *testing.T) {
+	hs, err := Load([]string{""}, Options{})
+	if err != nil {
+		t.Fatalf("expected no error loading the default lists, got: %v", err)
+	}
+	if len(hs) != 0 {
+		t.Fatalf("expected no holidays, got: %v", hs)
+	}
+}
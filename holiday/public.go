@@ -0,0 +1,252 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package holiday
+
+import "time"
+
+// day is a public holiday on a date.
+type day struct {
+	date time.Time
+	name string
+}
+
+// fixed returns a day on a month and day of the month in a year.
+func fixed(year int, m time.Month, d int, name string) day {
+	return day{date: time.Date(year, m, d, 0, 0, 0, 0, time.UTC), name: name}
+}
+
+// easter returns Easter Sunday in the Gregorian calendar using the anonymous
+// Gregorian algorithm.
+func easter(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	dd := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), dd, 0, 0, 0, 0, time.UTC)
+}
+
+// fromEaster returns a day offset from Easter Sunday.
+func fromEaster(year, offset int, name string) day {
+	return day{date: easter(year).AddDate(0, 0, offset), name: name}
+}
+
+// nth returns the nth weekday of a month, such as the third Monday. A
+// negative n counts from the end of the month, so -1 is the last.
+func nth(year int, m time.Month, w time.Weekday, n int, name string) day {
+	if n < 0 {
+		last := time.Date(year, m+1, 0, 0, 0, 0, 0, time.UTC)
+		offset := (int(last.Weekday()) - int(w) + 7) % 7
+		return day{date: last.AddDate(0, 0, -offset+(n+1)*7), name: name}
+	}
+	first := time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(w) - int(first.Weekday()) + 7) % 7
+	return day{date: first.AddDate(0, 0, offset+(n-1)*7), name: name}
+}
+
+// weekend reports if a day is a Saturday or Sunday.
+func weekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
+
+// mondayise moves holidays which fall on a weekend to the next weekday which
+// is not already a holiday, as in New Zealand and the United Kingdom. Holidays
+// on weekdays keep their day so when New Year's Day is a Sunday it is observed
+// on Tuesday after the 2nd of January on Monday.
+func mondayise(days ...day) []day {
+	taken := make(map[time.Time]bool)
+	for _, d := range days {
+		if !weekend(d.date) {
+			taken[d.date] = true
+		}
+	}
+	var observed []day
+	for _, d := range days {
+		if !weekend(d.date) {
+			continue
+		}
+		t := d.date
+		for weekend(t) || taken[t] {
+			t = t.AddDate(0, 0, 1)
+		}
+		taken[t] = true
+		observed = append(observed, day{date: t, name: d.name + " (observed)"})
+	}
+	return append(days, observed...)
+}
+
+// nearestWeekday moves a holiday on a Saturday to the Friday before and one on
+// a Sunday to the Monday after, as in the United States.
+func nearestWeekday(d day) []day {
+	switch d.date.Weekday() {
+	case time.Saturday:
+		return []day{d, {date: d.date.AddDate(0, 0, -1), name: d.name + " (observed)"}}
+	case time.Sunday:
+		return []day{d, {date: d.date.AddDate(0, 0, 1), name: d.name + " (observed)"}}
+	}
+	return []day{d}
+}
+
+// public creates a generator for a set of public holidays.
+func public(rules func(year int) []day) generator {
+	return func(year int, _ *time.Location) Holidays {
+		var holidays Holidays
+		for _, d := range rules(year) {
+			holidays = append(holidays, Holiday{
+				Date:    d.date.Format("2006-01-02"),
+				Message: d.name,
+			})
+		}
+		return holidays
+	}
+}
+
+// matariki are the dates of the Matariki public holiday in New Zealand, which
+// follow the lunar calendar. They are set by the Te Kāhui o Matariki Public
+// Holiday Act 2022 only up to 2052, so later years have no Matariki.
+var matariki = map[int]time.Time{
+	2022: time.Date(2022, time.June, 24, 0, 0, 0, 0, time.UTC),
+	2023: time.Date(2023, time.July, 14, 0, 0, 0, 0, time.UTC),
+	2024: time.Date(2024, time.June, 28, 0, 0, 0, 0, time.UTC),
+	2025: time.Date(2025, time.June, 20, 0, 0, 0, 0, time.UTC),
+	2026: time.Date(2026, time.July, 10, 0, 0, 0, 0, time.UTC),
+	2027: time.Date(2027, time.June, 25, 0, 0, 0, 0, time.UTC),
+	2028: time.Date(2028, time.July, 14, 0, 0, 0, 0, time.UTC),
+	2029: time.Date(2029, time.July, 6, 0, 0, 0, 0, time.UTC),
+	2030: time.Date(2030, time.June, 21, 0, 0, 0, 0, time.UTC),
+	2031: time.Date(2031, time.July, 11, 0, 0, 0, 0, time.UTC),
+	2032: time.Date(2032, time.July, 2, 0, 0, 0, 0, time.UTC),
+	2033: time.Date(2033, time.June, 24, 0, 0, 0, 0, time.UTC),
+	2034: time.Date(2034, time.July, 7, 0, 0, 0, 0, time.UTC),
+	2035: time.Date(2035, time.June, 29, 0, 0, 0, 0, time.UTC),
+	2036: time.Date(2036, time.July, 18, 0, 0, 0, 0, time.UTC),
+	2037: time.Date(2037, time.July, 10, 0, 0, 0, 0, time.UTC),
+	2038: time.Date(2038, time.June, 25, 0, 0, 0, 0, time.UTC),
+	2039: time.Date(2039, time.July, 15, 0, 0, 0, 0, time.UTC),
+	2040: time.Date(2040, time.July, 6, 0, 0, 0, 0, time.UTC),
+	2041: time.Date(2041, time.July, 19, 0, 0, 0, 0, time.UTC),
+	2042: time.Date(2042, time.July, 11, 0, 0, 0, 0, time.UTC),
+	2043: time.Date(2043, time.July, 3, 0, 0, 0, 0, time.UTC),
+	2044: time.Date(2044, time.June, 24, 0, 0, 0, 0, time.UTC),
+	2045: time.Date(2045, time.July, 7, 0, 0, 0, 0, time.UTC),
+	2046: time.Date(2046, time.June, 29, 0, 0, 0, 0, time.UTC),
+	2047: time.Date(2047, time.July, 19, 0, 0, 0, 0, time.UTC),
+	2048: time.Date(2048, time.July, 3, 0, 0, 0, 0, time.UTC),
+	2049: time.Date(2049, time.June, 25, 0, 0, 0, 0, time.UTC),
+	2050: time.Date(2050, time.July, 15, 0, 0, 0, 0, time.UTC),
+	2051: time.Date(2051, time.June, 30, 0, 0, 0, 0, time.UTC),
+	2052: time.Date(2052, time.June, 21, 0, 0, 0, 0, time.UTC),
+}
+
+// nz are the national public holidays of New Zealand. Regional anniversary
+// days are not included.
+func nz(year int) []day {
+	days := mondayise(
+		fixed(year, time.January, 1, "New Year's Day"),
+		fixed(year, time.January, 2, "Day after New Year's Day"),
+	)
+	if year >= 2014 {
+		days = append(days, mondayise(fixed(year, time.February, 6, "Waitangi Day"))...)
+		days = append(days, mondayise(fixed(year, time.April, 25, "Anzac Day"))...)
+	} else {
+		days = append(days,
+			fixed(year, time.February, 6, "Waitangi Day"),
+			fixed(year, time.April, 25, "Anzac Day"),
+		)
+	}
+	sovereign := "King's Birthday"
+	if year < 2023 {
+		sovereign = "Queen's Birthday"
+	}
+	days = append(days,
+		fromEaster(year, -2, "Good Friday"),
+		fromEaster(year, 1, "Easter Monday"),
+		nth(year, time.June, time.Monday, 1, sovereign),
+		nth(year, time.October, time.Monday, 4, "Labour Day"),
+	)
+	if t, ok := matariki[year]; ok {
+		days = append(days, day{date: t, name: "Matariki"})
+	}
+	days = append(days, mondayise(
+		fixed(year, time.December, 25, "Christmas Day"),
+		fixed(year, time.December, 26, "Boxing Day"),
+	)...)
+	return days
+}
+
+// us are the federal holidays of the United States.
+func us(year int) []day {
+	var days []day
+	days = append(days, nearestWeekday(fixed(year, time.January, 1, "New Year's Day"))...)
+	days = append(days,
+		nth(year, time.January, time.Monday, 3, "Martin Luther King Jr. Day"),
+		nth(year, time.February, time.Monday, 3, "Washington's Birthday"),
+		nth(year, time.May, time.Monday, -1, "Memorial Day"),
+	)
+	if year >= 2021 {
+		days = append(days, nearestWeekday(fixed(year, time.June, 19, "Juneteenth"))...)
+	}
+	days = append(days, nearestWeekday(fixed(year, time.July, 4, "Independence Day"))...)
+	days = append(days,
+		nth(year, time.September, time.Monday, 1, "Labor Day"),
+		nth(year, time.October, time.Monday, 2, "Columbus Day"),
+	)
+	days = append(days, nearestWeekday(fixed(year, time.November, 11, "Veterans Day"))...)
+	days = append(days, nth(year, time.November, time.Thursday, 4, "Thanksgiving Day"))
+	days = append(days, nearestWeekday(fixed(year, time.December, 25, "Christmas Day"))...)
+	return days
+}
+
+// gb are the bank holidays of England and Wales. One-off holidays, such as for
+// coronations and jubilees, are not included.
+func gb(year int) []day {
+	days := mondayise(fixed(year, time.January, 1, "New Year's Day"))
+	days = append(days,
+		fromEaster(year, -2, "Good Friday"),
+		fromEaster(year, 1, "Easter Monday"),
+		nth(year, time.May, time.Monday, 1, "Early May bank holiday"),
+		nth(year, time.May, time.Monday, -1, "Spring bank holiday"),
+		nth(year, time.August, time.Monday, -1, "Summer bank holiday"),
+	)
+	days = append(days, mondayise(
+		fixed(year, time.December, 25, "Christmas Day"),
+		fixed(year, time.December, 26, "Boxing Day"),
+	)...)
+	return days
+}
+
+// de are the national public holidays of Germany. Holidays are not moved when
+// they fall on a weekend.
+func de(year int) []day {
+	return []day{
+		fixed(year, time.January, 1, "Neujahr"),
+		fromEaster(year, -2, "Karfreitag"),
+		fromEaster(year, 1, "Ostermontag"),
+		fixed(year, time.May, 1, "Tag der Arbeit"),
+		fromEaster(year, 39, "Christi Himmelfahrt"),
+		fromEaster(year, 50, "Pfingstmontag"),
+		fixed(year, time.October, 3, "Tag der Deutschen Einheit"),
+		fixed(year, time.December, 25, "1. Weihnachtstag"),
+		fixed(year, time.December, 26, "2. Weihnachtstag"),
+	}
+}
+
+// deBY are the public holidays of Bavaria, Germany. Mariä Himmelfahrt is only
+// a holiday in mostly Catholic municipalities, which are most of them.
+func deBY(year int) []day {
+	return append(de(year),
+		fixed(year, time.January, 6, "Heilige Drei Könige"),
+		fromEaster(year, 60, "Fronleichnam"),
+		fixed(year, time.August, 15, "Mariä Himmelfahrt"),
+		fixed(year, time.November, 1, "Allerheiligen"),
+	)
+}
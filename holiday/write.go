@@ -0,0 +1,97 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package holiday
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Recurrence is how often a holiday written to a list repeats.
+type Recurrence uint8
+
+const (
+	// Once is a holiday on a single date.
+	Once Recurrence = iota
+	// Yearly is a holiday on the same month and day every year.
+	Yearly
+	// Monthly is a holiday on the same day every month.
+	Monthly
+)
+
+// ParseRecurrence reads a recurrence written as once, yearly, or monthly, or
+// the first letter of one. An empty string is Once.
+func ParseRecurrence(s string) (Recurrence, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "o", "once":
+		return Once, nil
+	case "y", "yearly":
+		return Yearly, nil
+	case "m", "monthly":
+		return Monthly, nil
+	}
+	return Once, fmt.Errorf("unknown recurrence %v", s)
+}
+
+// Line formats a holiday on time t as a line of a holiday list, quoting the
+// message if it is needed to read it back unchanged.
+func Line(t time.Time, r Recurrence, color, message string) string {
+	layout := "2006-01-02"
+	switch r {
+	case Yearly:
+		layout = "01-02"
+	case Monthly:
+		layout = "02"
+	}
+	line := t.Format(layout) + " " + color
+	if message != "" {
+		line += " " + quote(message)
+	}
+	return line
+}
+
+// quote wraps a message in double quotes if it contains characters which
+// would otherwise be read differently, such as a # or repeated spaces.
+func quote(s string) string {
+	if !strings.ContainsAny(s, "#\"\\\t") &&
+		!strings.Contains(s, "  ") &&
+		strings.TrimSpace(s) == s {
+		return s
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// Append writes a line to the end of a holiday list, creating the list if it
+// does not exist.
+func Append(path, line string) error {
+	f, err := os.OpenFile(
+		os.ExpandEnv(path),
+		os.O_RDWR|os.O_CREATE|os.O_APPEND,
+		0o644,
+	)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// Start a new line if the list does not end with one.
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err := f.ReadAt(last, info.Size()-1); err != nil && err != io.EOF {
+			return err
+		}
+		if last[0] != '\n' {
+			line = "\n" + line
+		}
+	}
+	_, err = f.WriteString(line + "\n")
+	return err
+}
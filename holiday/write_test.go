@@ -0,0 +1,69 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package holiday
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holidays")
+	// The existing list is missing its final newline.
+	if err := os.WriteFile(path, []byte("01-01 1 New year"), 0o644); err != nil {
+		t.Fatalf("failed writing list: %v", err)
+	}
+
+	day := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC)
+	lines := []string{
+		Line(day, Once, "2", "Pi day"),
+		Line(day, Yearly, "5", `Party # "bring cake"`),
+		Line(day, Monthly, "6", ""),
+	}
+	for _, l := range lines {
+		if err := Append(path, l); err != nil {
+			t.Fatalf("failed appending %q: %v", l, err)
+		}
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed reading list: %v", err)
+	}
+	want := "01-01 1 New year\n" +
+		"2025-03-14 2 Pi day\n" +
+		"03-14 5 \"Party # \\\"bring cake\\\"\"\n" +
+		"14 6\n"
+	if string(b) != want {
+		t.Fatalf("want: %q, got: %q", want, string(b))
+	}
+
+	hs, err := load(path)
+	if err != nil {
+		t.Fatalf("failed loading list: %v", err)
+	}
+	messages := hs.MatchAll(day.AddDate(1, 0, 0)).Messages()
+	if len(messages) != 1 || messages[0] != `Party # "bring cake"` {
+		t.Fatalf("message was not read back unchanged: %q", messages)
+	}
+}
+
+func TestParseRecurrence(t *testing.T) {
+	tests := map[string]Recurrence{
+		"":        Once,
+		"once":    Once,
+		"Y":       Yearly,
+		"monthly": Monthly,
+	}
+	for s, want := range tests {
+		got, err := ParseRecurrence(s)
+		if err != nil || got != want {
+			t.Fatalf("%q want: %v, got: %v %v", s, want, got, err)
+		}
+	}
+	if _, err := ParseRecurrence("weekly"); err == nil {
+		t.Fatalf("expected an error for an unknown recurrence")
+	}
+}
@@ -0,0 +1,524 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package index
+
+import (
+	"bufio"
+	"encoding/gob"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"git.sr.ht/~kota/calendar/keyword"
+	gap "github.com/muesli/go-app-paths"
+)
+
+// version of the index file format. An index written with another version is
+// rebuilt.
+const version = 3
+
+// maxToken is the length of the longest word kept in the token index. Longer
+// words, such as URLs, are left out to keep the index small, and notes
+// containing them are always searched.
+const maxToken = 64
+
+// tagPattern matches tags such as #work in a note. Markdown headings are not
+// tags as they are followed by a space.
+var tagPattern = regexp.MustCompile(`(?:^|\s)#([\p{L}\p{N}_-]+)`)
+
+// Index is a persistent index of the notes in a directory. It is stored in the
+// user's cache directory and updated incrementally, so only notes whose size or
+// modification time has changed are read again. It is safe for concurrent
+// use, so months may style their days from it in the background.
+type Index struct {
+	mu      sync.RWMutex
+	Version int
+	// Dir is the expanded note directory the index was built from.
+	Dir string
+	// Keywords are the keywords searched for in each note.
+	Keywords []string
+	// Notes maps the date of each note, as 2006-01-02, to what is known
+	// about it. Empty notes are left out.
+	Notes map[string]Note
+	// Tokens maps each lowercased word to the days whose notes contain it, as
+	// days since the Unix epoch in ascending order.
+	Tokens map[string][]int32
+}
+
+// Note is what is indexed about a single note.
+type Note struct {
+	Size    int64
+	ModTime time.Time
+	// Matches are the keywords found in the note, in the order they are
+	// first found.
+	Matches []Match
+	// Tags are the note's tags, such as "work" for #work, in the order they
+	// first appear.
+	Tags []string
+	// Long is set if the note has words longer than maxToken, which are not in
+	// the token index.
+	Long bool
+}
+
+// Match is a keyword found in a note and the trimmed line it was first found
+// on.
+type Match struct {
+	Keyword string
+	Line    string
+}
+
+// Match returns the first of some keywords found in the note and the line it
+// was found on, like keyword.Keywords.MatchLine. Only the keywords the index
+// was built with are found.
+func (n Note) Match(ks keyword.Keywords) (keyword.Keyword, string, bool) {
+	for _, m := range n.Matches {
+		for _, k := range ks {
+			if k.Keyword == m.Keyword {
+				return k, m.Line, true
+			}
+		}
+	}
+	return keyword.Keyword{}, "", false
+}
+
+// Stats describe an index and the last update to it.
+type Stats struct {
+	Notes   int
+	Updated int
+	Removed int
+	Tags    int
+	Words   int
+}
+
+// Path returns the path of the index file in the user's cache directory.
+func Path() (string, error) {
+	scope := gap.NewScope(gap.User, "calendar")
+	dir, err := scope.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "index"), nil
+}
+
+// New creates an empty index.
+func New() *Index {
+	return &Index{
+		Version: version,
+		Notes:   make(map[string]Note),
+		Tokens:  make(map[string][]int32),
+	}
+}
+
+// Load reads an index file. A missing, unreadable, or outdated index is
+// treated as an empty one, which is rebuilt by the next update.
+func Load(path string) (*Index, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return New(), nil
+		}
+		return New(), err
+	}
+	defer f.Close()
+
+	var ix Index
+	if err := gob.NewDecoder(f).Decode(&ix); err != nil {
+		return New(), nil
+	}
+	if ix.Version != version || ix.Notes == nil || ix.Tokens == nil {
+		return New(), nil
+	}
+	return &ix, nil
+}
+
+// Save writes the index to a file, creating its directory if needed. The file
+// is replaced at once so a reader never sees a partly written index.
+func (ix *Index) Save(path string) error {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), ".index-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err := gob.NewEncoder(f).Encode(ix); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// Update brings the index up to date with the notes in a directory, reading
+// only the notes which are new or whose size or modification time changed. If
+// the directory or keywords differ from those the index was built with it is
+// rebuilt from scratch.
+//
+// Environment variables, such as $HOME, may be used in the dir.
+func (ix *Index) Update(dir string, keywords keyword.Keywords) (Stats, error) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	dir = os.ExpandEnv(dir)
+	words := keywordStrings(keywords)
+	if ix.Dir != dir || !equal(ix.Keywords, words) {
+		ix.Version = version
+		ix.Dir = dir
+		ix.Keywords = words
+		ix.Notes = make(map[string]Note)
+		ix.Tokens = make(map[string][]int32)
+	}
+
+	var stats Stats
+	entries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return stats, err
+	}
+
+	seen := make(map[string]bool)
+	changed := make(map[string]Note)
+	for _, entry := range entries {
+		day, ok := noteDay(entry.Name())
+		if !ok || entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || info.Size() == 0 {
+			continue
+		}
+		seen[day] = true
+		old, ok := ix.Notes[day]
+		if ok && old.Size == info.Size() && old.ModTime.Equal(info.ModTime()) {
+			continue
+		}
+		changed[day] = Note{Size: info.Size(), ModTime: info.ModTime()}
+	}
+
+	var stale []string
+	for day := range ix.Notes {
+		if !seen[day] {
+			stale = append(stale, day)
+			stats.Removed++
+		}
+	}
+	for day := range changed {
+		if _, ok := ix.Notes[day]; ok {
+			stale = append(stale, day)
+		}
+	}
+	ix.forget(stale)
+
+	for day, n := range changed {
+		data, err := os.ReadFile(filepath.Join(dir, day+".md"))
+		if err != nil {
+			continue
+		}
+		ix.add(day, n, string(data))
+		stats.Updated++
+	}
+	ix.sortTokens()
+	return ix.fill(stats), nil
+}
+
+// UpdateDay indexes the note for a single day again, such as after it was
+// edited, without reading the rest of the directory.
+func (ix *Index) UpdateDay(t time.Time) error {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	day := t.Format("2006-01-02")
+	ix.forget([]string{day})
+	path := filepath.Join(ix.Dir, day+".md")
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() || info.Size() == 0 {
+		if errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		}
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	ix.add(day, Note{Size: info.Size(), ModTime: info.ModTime()}, string(data))
+	ix.sortTokens()
+	return nil
+}
+
+// Stats describes the index as it is now.
+func (ix *Index) Stats() Stats {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return ix.fill(Stats{})
+}
+
+// Dates lists the days which have a note in ascending order.
+func (ix *Index) Dates(location *time.Location) []time.Time {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	days := make([]string, 0, len(ix.Notes))
+	for day := range ix.Notes {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+
+	dates := make([]time.Time, 0, len(days))
+	for _, day := range days {
+		t, err := time.ParseInLocation("2006-01-02", day, location)
+		if err == nil {
+			dates = append(dates, t)
+		}
+	}
+	return dates
+}
+
+// Note returns what is indexed about the note for time t, if it has one.
+func (ix *Index) Note(t time.Time) (Note, bool) {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	n, ok := ix.Notes[t.Format("2006-01-02")]
+	return n, ok
+}
+
+// Keyword returns the first keyword found in the note for time t and the line
+// containing it.
+func (ix *Index) Keyword(t time.Time) (string, string, bool) {
+	n, ok := ix.Note(t)
+	if !ok || len(n.Matches) == 0 {
+		return "", "", false
+	}
+	return n.Matches[0].Keyword, n.Matches[0].Line, true
+}
+
+// Tags counts how many notes use each tag.
+func (ix *Index) Tags() map[string]int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return ix.tags()
+}
+
+// tags is like Tags, but the caller must hold the lock.
+func (ix *Index) tags() map[string]int {
+	tags := make(map[string]int)
+	for _, n := range ix.Notes {
+		for _, tag := range n.Tags {
+			tags[tag]++
+		}
+	}
+	return tags
+}
+
+// Candidates returns the days, as 2006-01-02, whose notes may contain some
+// text. Every note containing the text is included, but some which do not may
+// be too, so the notes must still be read to be sure. Notes with words too long
+// to index are always included. If the text has no words the index cannot
+// narrow the search and false is returned.
+func (ix *Index) Candidates(text string) (map[string]bool, bool) {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	words, _ := tokens(text)
+	if len(words) == 0 {
+		return nil, false
+	}
+
+	var days map[string]bool
+	for _, w := range words {
+		// The searched text may start or end part way through a word, so any
+		// word containing it matches.
+		found := make(map[string]bool)
+		for token, postings := range ix.Tokens {
+			if !strings.Contains(token, w) {
+				continue
+			}
+			for _, p := range postings {
+				day := fromDays(p)
+				if days == nil || days[day] {
+					found[day] = true
+				}
+			}
+		}
+		days = found
+	}
+	for day, n := range ix.Notes {
+		if n.Long {
+			days[day] = true
+		}
+	}
+	return days, true
+}
+
+// forget removes notes from the index.
+func (ix *Index) forget(days []string) {
+	if len(days) == 0 {
+		return
+	}
+	drop := make(map[int32]bool)
+	for _, day := range days {
+		if _, ok := ix.Notes[day]; !ok {
+			continue
+		}
+		delete(ix.Notes, day)
+		drop[toDays(day)] = true
+	}
+	if len(drop) == 0 {
+		return
+	}
+	for token, postings := range ix.Tokens {
+		kept := postings[:0]
+		for _, p := range postings {
+			if !drop[p] {
+				kept = append(kept, p)
+			}
+		}
+		if len(kept) == 0 {
+			delete(ix.Tokens, token)
+		} else {
+			ix.Tokens[token] = kept
+		}
+	}
+}
+
+// add indexes the text of a note. The token index must be sorted afterwards.
+func (ix *Index) add(day string, n Note, text string) {
+	n.Matches = matches(ix.Keywords, text)
+
+	seen := make(map[string]bool)
+	for _, m := range tagPattern.FindAllStringSubmatch(text, -1) {
+		tag := strings.ToLower(m[1])
+		if !seen[tag] {
+			seen[tag] = true
+			n.Tags = append(n.Tags, tag)
+		}
+	}
+	words, long := tokens(text)
+	n.Long = long
+	ix.Notes[day] = n
+
+	d := toDays(day)
+	for _, w := range words {
+		ix.Tokens[w] = append(ix.Tokens[w], d)
+	}
+}
+
+// sortTokens puts the days of each token back in ascending order after notes
+// were added.
+func (ix *Index) sortTokens() {
+	for _, postings := range ix.Tokens {
+		if !sort.SliceIsSorted(postings, func(i, j int) bool {
+			return postings[i] < postings[j]
+		}) {
+			sort.Slice(postings, func(i, j int) bool {
+				return postings[i] < postings[j]
+			})
+		}
+	}
+}
+
+// fill adds the size of the index to some stats.
+func (ix *Index) fill(stats Stats) Stats {
+	stats.Notes = len(ix.Notes)
+	stats.Tags = len(ix.tags())
+	stats.Words = len(ix.Tokens)
+	return stats
+}
+
+// matches finds each keyword in text and the line it is first found on, like
+// keyword.Keywords.MatchAll.
+func matches(keywords []string, text string) []Match {
+	var found []Match
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		line := scanner.Text()
+		for _, k := range keywords {
+			if !seen[k] && strings.Contains(line, k) {
+				seen[k] = true
+				found = append(found, Match{
+					Keyword: k,
+					Line:    strings.TrimSpace(line),
+				})
+			}
+		}
+	}
+	return found
+}
+
+// tokens splits text into its distinct lowercased words and reports if any
+// were left out for being longer than maxToken.
+func tokens(text string) ([]string, bool) {
+	seen := make(map[string]bool)
+	var words []string
+	var long bool
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	for _, f := range fields {
+		if len(f) > maxToken {
+			long = true
+			continue
+		}
+		if seen[f] {
+			continue
+		}
+		seen[f] = true
+		words = append(words, f)
+	}
+	return words, long
+}
+
+// noteDay returns the date of a note from its file name, such as 2006-01-02
+// from 2006-01-02.md.
+func noteDay(name string) (string, bool) {
+	if filepath.Ext(name) != ".md" {
+		return "", false
+	}
+	day := strings.TrimSuffix(name, ".md")
+	if _, err := time.Parse("2006-01-02", day); err != nil {
+		return "", false
+	}
+	return day, true
+}
+
+// toDays converts a date, as 2006-01-02, to days since the Unix epoch.
+func toDays(day string) int32 {
+	t, _ := time.Parse("2006-01-02", day)
+	return int32(t.Unix() / (24 * 60 * 60))
+}
+
+// fromDays converts days since the Unix epoch to a date, as 2006-01-02.
+func fromDays(d int32) string {
+	return time.Unix(int64(d)*24*60*60, 0).UTC().Format("2006-01-02")
+}
+
+// keywordStrings returns the text of each keyword.
+func keywordStrings(ks keyword.Keywords) []string {
+	words := make([]string, len(ks))
+	for i, k := range ks {
+		words[i] = k.Keyword
+	}
+	return words
+}
+
+// equal reports if two lists of strings are the same.
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,150 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package index
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"git.sr.ht/~kota/calendar/keyword"
+)
+
+func TestIndex(t *testing.T) {
+	dir := t.TempDir()
+	write := func(day, text string) {
+		t.Helper()
+		path := filepath.Join(dir, day+".md")
+		if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+			t.Fatalf("failed writing note: %v", err)
+		}
+	}
+	write("2015-06-01", "# Plans\n\nDentist at 9 #health\n")
+	write("2023-01-02", "Lunch with Alice #work #Health\n")
+	write("2023-01-03", "")
+	write("notes", "not a note")
+	keywords := keyword.Keywords{{Keyword: "Dentist"}, {Keyword: "Lunch"}}
+
+	ix := New()
+	stats, err := ix.Update(dir, keywords)
+	if err != nil {
+		t.Fatalf("failed updating index: %v", err)
+	}
+	want := Stats{Notes: 2, Updated: 2, Tags: 2, Words: 9}
+	if stats != want {
+		t.Fatalf("want: %+v, got: %+v", want, stats)
+	}
+
+	// An unchanged directory reads nothing again, even after saving and
+	// loading the index.
+	path := filepath.Join(t.TempDir(), "cache", "index")
+	if err := ix.Save(path); err != nil {
+		t.Fatalf("failed saving index: %v", err)
+	}
+	ix, err = Load(path)
+	if err != nil {
+		t.Fatalf("failed loading index: %v", err)
+	}
+	if stats, _ := ix.Update(dir, keywords); stats.Updated != 0 {
+		t.Fatalf("want no notes read again, got: %+v", stats)
+	}
+
+	day := time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)
+	if k, line, ok := ix.Keyword(day); !ok || k != "Dentist" || line != "Dentist at 9 #health" {
+		t.Fatalf("unexpected keyword: %q %q %v", k, line, ok)
+	}
+	if got := ix.Tags(); !reflect.DeepEqual(got, map[string]int{"health": 2, "work": 1}) {
+		t.Fatalf("unexpected tags: %v", got)
+	}
+	days, ok := ix.Candidates("ice health")
+	if !ok || !reflect.DeepEqual(days, map[string]bool{"2023-01-02": true}) {
+		t.Fatalf("unexpected candidates: %v %v", days, ok)
+	}
+	if _, ok := ix.Candidates("!?"); ok {
+		t.Fatalf("expected text without words not to narrow the search")
+	}
+
+	// Changing and removing notes only updates those notes.
+	write("2023-01-02", "Nothing planned\n")
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "2023-01-02.md"), later, later); err != nil {
+		t.Fatalf("failed changing note times: %v", err)
+	}
+	if err := os.Remove(filepath.Join(dir, "2015-06-01.md")); err != nil {
+		t.Fatalf("failed removing note: %v", err)
+	}
+	stats, err = ix.Update(dir, keywords)
+	if err != nil {
+		t.Fatalf("failed updating index: %v", err)
+	}
+	want = Stats{Notes: 1, Updated: 1, Removed: 1, Tags: 0, Words: 2}
+	if stats != want {
+		t.Fatalf("want: %+v, got: %+v", want, stats)
+	}
+	if dates := ix.Dates(time.UTC); len(dates) != 1 || dates[0].Format("2006-01-02") != "2023-01-02" {
+		t.Fatalf("unexpected dates: %v", dates)
+	}
+	if days, ok := ix.Candidates("alice"); !ok || len(days) != 0 {
+		t.Fatalf("expected the old words to be forgotten, got: %v", days)
+	}
+
+	// Changing the keywords rebuilds the index.
+	stats, _ = ix.Update(dir, keyword.Keywords{{Keyword: "planned"}})
+	if stats.Updated != 1 {
+		t.Fatalf("want the index rebuilt, got: %+v", stats)
+	}
+}
+
+func TestLongWords(t *testing.T) {
+	dir := t.TempDir()
+	hash := strings.Repeat("0123456789abcdef", 5)
+	notes := map[string]string{
+		"2023-01-02": "commit " + hash + "\n",
+		"2023-01-03": "nothing here\n",
+	}
+	for day, text := range notes {
+		path := filepath.Join(dir, day+".md")
+		if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+			t.Fatalf("failed writing note: %v", err)
+		}
+	}
+
+	ix := New()
+	if _, err := ix.Update(dir, nil); err != nil {
+		t.Fatalf("failed updating index: %v", err)
+	}
+	// The hash is too long to index, so its note must still be searched for
+	// text found only within it.
+	days, ok := ix.Candidates("89abcdef0123")
+	if !ok || !reflect.DeepEqual(days, map[string]bool{"2023-01-02": true}) {
+		t.Fatalf("want the note with a long word, got: %v %v", days, ok)
+	}
+	if _, ok := ix.Tokens[hash]; ok {
+		t.Fatalf("expected the long word not to be indexed")
+	}
+}
+
+func TestNoteMatch(t *testing.T) {
+	text := "Lunch\n  Dentist at 9\nLunch again\n"
+	keywords := keyword.Keywords{{Keyword: "Dentist"}, {Keyword: "Lunch"}}
+	n := Note{Matches: matches([]string{"Dentist", "Lunch"}, text)}
+
+	// Matching any subset of the keywords finds the same line as reading the
+	// note does.
+	for _, ks := range []keyword.Keywords{
+		keywords,
+		keywords[:1],
+		keywords[1:],
+		{{Keyword: "Release"}},
+	} {
+		wantK, wantLine, wantOK := ks.MatchLine(strings.NewReader(text))
+		k, line, ok := n.Match(ks)
+		if k != wantK || line != strings.TrimSpace(wantLine) || ok != wantOK {
+			t.Fatalf("matching %v: want: %v %q %v, got: %v %q %v",
+				ks, wantK, wantLine, wantOK, k, line, ok)
+		}
+	}
+}
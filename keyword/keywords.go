@@ -9,19 +9,44 @@ import (
 type Keywords []Keyword
 
 func (ks Keywords) Match(r io.Reader) (Keyword, bool) {
+	k, _, ok := ks.MatchLine(r)
+	return k, ok
+}
+
+// MatchLine is like Match, but also returns the line containing the keyword.
+func (ks Keywords) MatchLine(r io.Reader) (Keyword, string, bool) {
 	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		line := scanner.Text()
 		for _, k := range ks {
 			if strings.Contains(line, k.Keyword) {
-				return k, true
+				return k, line, true
+			}
+		}
+	}
+	return Keyword{}, "", false
+}
+
+// MatchAll returns every keyword found in r, in the order they are first
+// found.
+func (ks Keywords) MatchAll(r io.Reader) Keywords {
+	var found Keywords
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		for _, k := range ks {
+			if !seen[k.Keyword] && strings.Contains(line, k.Keyword) {
+				seen[k.Keyword] = true
+				found = append(found, k)
 			}
 		}
 	}
-	return Keyword{}, false
+	return found
 }
 
 type Keyword struct {
-	Keyword string
-	Color   string
+	Keyword   string
+	Color     string
+	Countdown bool
 }
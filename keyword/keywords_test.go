@@ -66,3 +66,15 @@ func TestMatch(t *testing.T) {
 		}
 	}
 }
+
+func TestMatchAll(t *testing.T) {
+	ks := Keywords{{Keyword: "ATTN"}, {Keyword: "TODO"}, {Keyword: "DONE"}}
+	r := strings.NewReader("TODO: call\nATTN\nTODO: write\n")
+	want := Keywords{{Keyword: "TODO"}, {Keyword: "ATTN"}}
+	if got := ks.MatchAll(r); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %v, want: %v", got, want)
+	}
+	if got := ks.MatchAll(strings.NewReader("nothing")); len(got) != 0 {
+		t.Fatalf("got: %v, want no keywords", got)
+	}
+}
@@ -0,0 +1,155 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package locale
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// Locale holds the translated names and date layouts for a language.
+type Locale struct {
+	// Language is the two letter language code, such as "de".
+	Language string
+	Months   [12]string
+	// ShortMonths replace "Jan" in date layouts.
+	ShortMonths [12]string
+	Days        [7]string
+	// ShortDays replace "Mon" in date layouts.
+	ShortDays [7]string
+	// Weekdays are the column headings above each month. Each should be at
+	// most two columns wide.
+	Weekdays [7]string
+	// MonthYear is the layout used for the heading of a month.
+	MonthYear string
+	// LongDate is the layout used to describe the selected day.
+	LongDate string
+	// DayOfYear and Week describe the day of the year and the ISO week of the
+	// selected day, with %v replaced by the number.
+	DayOfYear string
+	Week      string
+	// Today, Tomorrow, and Yesterday describe the selected day relative to
+	// today. Later and Earlier describe days further away, with %v replaced
+	// by the number of days.
+	Today     string
+	Tomorrow  string
+	Yesterday string
+	Later     string
+	Earlier   string
+	// Phases are the names of the new moon, first quarter, full moon, and
+	// last quarter.
+	Phases [4]string
+	// Sunrise, Sunset, and Daylight describe the times of sunrise and sunset
+	// and the length of the day, with %v replaced by the time or length.
+	// MidnightSun and PolarNight describe days when the sun never sets or
+	// never rises.
+	Sunrise     string
+	Sunset      string
+	Daylight    string
+	MidnightSun string
+	PolarNight  string
+	// DayCount and BusinessDays count days and business days, with %v
+	// replaced by the number. The first form is used for one day and the
+	// second for any other number.
+	DayCount     [2]string
+	BusinessDays [2]string
+}
+
+// English is the default locale.
+var English = locales["en"]
+
+// Get returns the locale for a name such as "de", "de_DE", or
+// "de_DE.UTF-8". Unknown names, including "C" and "POSIX", are English.
+func Get(name string) Locale {
+	if i := strings.IndexAny(name, ".@"); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.IndexAny(name, "_-"); i >= 0 {
+		name = name[:i]
+	}
+	if l, ok := locales[strings.ToLower(name)]; ok {
+		return l
+	}
+	return English
+}
+
+// Relative describes a day some number of days from today, such as "in 3
+// days". Negative days are before today.
+func (l Locale) Relative(days int) string {
+	switch {
+	case days == 0:
+		return l.Today
+	case days == 1:
+		return l.Tomorrow
+	case days == -1:
+		return l.Yesterday
+	case days > 0:
+		return fmt.Sprintf(l.Later, days)
+	default:
+		return fmt.Sprintf(l.Earlier, -days)
+	}
+}
+
+// Count formats n using the first of two forms if n is 1 and the second
+// otherwise, such as l.Count(l.DayCount, 3) for "3 days".
+func (l Locale) Count(forms [2]string, n int) string {
+	if n == 1 {
+		return fmt.Sprintf(forms[0], n)
+	}
+	return fmt.Sprintf(forms[1], n)
+}
+
+// tokens are the parts of a Go time layout which contain names. Longer tokens
+// come first so "January" is not read as "Jan".
+var tokens = []string{"January", "Monday", "Jan", "Mon"}
+
+// Format is like time.Time.Format, but writes month and weekday names in this
+// locale.
+func (l Locale) Format(t time.Time, layout string) string {
+	var b strings.Builder
+	for {
+		i, token := nextToken(layout)
+		if i < 0 {
+			b.WriteString(t.Format(layout))
+			return b.String()
+		}
+		b.WriteString(t.Format(layout[:i]))
+		switch token {
+		case "January":
+			b.WriteString(l.Months[t.Month()-1])
+		case "Monday":
+			b.WriteString(l.Days[t.Weekday()])
+		case "Jan":
+			b.WriteString(l.ShortMonths[t.Month()-1])
+		case "Mon":
+			b.WriteString(l.ShortDays[t.Weekday()])
+		}
+		layout = layout[i+len(token):]
+	}
+}
+
+// nextToken finds the first name token in a layout and its index. The index is
+// -1 if there are none.
+func nextToken(layout string) (int, string) {
+	for i := range layout {
+		for _, token := range tokens {
+			if strings.HasPrefix(layout[i:], token) {
+				return i, token
+			}
+		}
+	}
+	return -1, ""
+}
+
+// WeekdayHeading returns the weekday headings starting from Sunday, each
+// padded to two columns and separated by a space.
+func (l Locale) WeekdayHeading() string {
+	cells := make([]string, len(l.Weekdays))
+	for i, d := range l.Weekdays {
+		cells[i] = runewidth.FillRight(runewidth.Truncate(d, 2, ""), 2)
+	}
+	return strings.Join(cells, " ")
+}
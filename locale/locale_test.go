@@ -0,0 +1,141 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package locale
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGet(t *testing.T) {
+	type test struct {
+		name string
+		want string
+	}
+
+	tests := []test{
+		{name: "", want: "en"},
+		{name: "C", want: "en"},
+		{name: "POSIX", want: "en"},
+		{name: "de", want: "de"},
+		{name: "de_DE.UTF-8", want: "de"},
+		{name: "fr-CA", want: "fr"},
+		{name: "ja_JP.UTF-8@calendar", want: "ja"},
+		{name: "xx_XX", want: "en"},
+	}
+
+	for _, tc := range tests {
+		if got := Get(tc.name).Language; got != tc.want {
+			t.Fatalf("%q: want: %q, got: %q", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	day := time.Date(2023, time.March, 6, 9, 41, 0, 0, time.UTC)
+
+	type test struct {
+		locale string
+		layout string
+		want   string
+	}
+
+	tests := []test{
+		{locale: "en", layout: "Monday, 2 January 2006", want: "Monday, 6 March 2023"},
+		{locale: "de", layout: "Monday, 2. January 2006", want: "Montag, 6. März 2023"},
+		{locale: "de", layout: "Mon 2 Jan 15:04", want: "Mo 6 Mär 09:41"},
+		{locale: "fr", layout: "2006-01-02", want: "2023-03-06"},
+		{locale: "ja", layout: "2006年January", want: "2023年3月"},
+	}
+
+	for _, tc := range tests {
+		got := Get(tc.locale).Format(day, tc.layout)
+		if got != tc.want {
+			t.Fatalf("want: %q, got: %q", tc.want, got)
+		}
+	}
+}
+
+func TestWeekdayHeading(t *testing.T) {
+	type test struct {
+		locale string
+		want   string
+	}
+
+	tests := []test{
+		{locale: "en", want: "Su Mo Tu We Th Fr Sa"},
+		{locale: "fr", want: "di lu ma me je ve sa"},
+		{locale: "ja", want: "日 月 火 水 木 金 土"},
+	}
+
+	for _, tc := range tests {
+		got := Get(tc.locale).WeekdayHeading()
+		if got != tc.want {
+			t.Fatalf("want: %q, got: %q", tc.want, got)
+		}
+	}
+}
+
+func TestRelative(t *testing.T) {
+	type test struct {
+		locale string
+		days   int
+		want   string
+	}
+
+	tests := []test{
+		{locale: "en", days: 0, want: "today"},
+		{locale: "en", days: 1, want: "tomorrow"},
+		{locale: "en", days: -1, want: "yesterday"},
+		{locale: "en", days: 357, want: "in 357 days"},
+		{locale: "en", days: -13, want: "13 days ago"},
+		{locale: "de", days: 3, want: "in 3 Tagen"},
+		{locale: "de", days: -3, want: "vor 3 Tagen"},
+		{locale: "ja", days: 3, want: "3日後"},
+	}
+
+	for _, tc := range tests {
+		got := Get(tc.locale).Relative(tc.days)
+		if got != tc.want {
+			t.Fatalf("want: %q, got: %q", tc.want, got)
+		}
+	}
+
+	// Every locale has a translation for each description.
+	for name, l := range locales {
+		for _, s := range []string{
+			l.DayOfYear, l.Week,
+			l.Today, l.Tomorrow, l.Yesterday, l.Later, l.Earlier,
+			l.Phases[0], l.Phases[1], l.Phases[2], l.Phases[3],
+			l.Sunrise, l.Sunset, l.Daylight, l.MidnightSun, l.PolarNight,
+			l.DayCount[0], l.DayCount[1], l.BusinessDays[0], l.BusinessDays[1],
+		} {
+			if s == "" {
+				t.Fatalf("%v: missing a translation", name)
+			}
+		}
+	}
+}
+
+func TestCount(t *testing.T) {
+	type test struct {
+		locale string
+		n      int
+		want   string
+	}
+
+	tests := []test{
+		{locale: "en", n: 1, want: "1 business day"},
+		{locale: "en", n: 0, want: "0 business days"},
+		{locale: "en", n: 20, want: "20 business days"},
+		{locale: "de", n: 1, want: "1 Werktag"},
+		{locale: "de", n: 6, want: "6 Werktage"},
+	}
+
+	for _, tc := range tests {
+		l := Get(tc.locale)
+		if got := l.Count(l.BusinessDays, tc.n); got != tc.want {
+			t.Fatalf("want: %q, got: %q", tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package locale
+
+// locales are the supported locales by language code.
+var locales = map[string]Locale{
+	"en": {
+		Language: "en",
+		Months: [12]string{
+			"January", "February", "March", "April", "May", "June",
+			"July", "August", "September", "October", "November", "December",
+		},
+		ShortMonths: [12]string{
+			"Jan", "Feb", "Mar", "Apr", "May", "Jun",
+			"Jul", "Aug", "Sep", "Oct", "Nov", "Dec",
+		},
+		Days: [7]string{
+			"Sunday", "Monday", "Tuesday", "Wednesday",
+			"Thursday", "Friday", "Saturday",
+		},
+		ShortDays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		Weekdays:  [7]string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"},
+		MonthYear: "January 2006",
+		LongDate:  "Monday, 2 January 2006",
+		DayOfYear: "day %v",
+		Week:      "week %v",
+		Today:     "today",
+		Tomorrow:  "tomorrow",
+		Yesterday: "yesterday",
+		Later:     "in %v days",
+		Earlier:   "%v days ago",
+		Phases: [4]string{
+			"New moon", "First quarter", "Full moon", "Last quarter",
+		},
+		Sunrise:      "sunrise %v",
+		Sunset:       "sunset %v",
+		Daylight:     "%v daylight",
+		MidnightSun:  "midnight sun",
+		PolarNight:   "polar night",
+		DayCount:     [2]string{"%v day", "%v days"},
+		BusinessDays: [2]string{"%v business day", "%v business days"},
+	},
+	"de": {
+		Language: "de",
+		Months: [12]string{
+			"Januar", "Februar", "März", "April", "Mai", "Juni",
+			"Juli", "August", "September", "Oktober", "November", "Dezember",
+		},
+		ShortMonths: [12]string{
+			"Jan", "Feb", "Mär", "Apr", "Mai", "Jun",
+			"Jul", "Aug", "Sep", "Okt", "Nov", "Dez",
+		},
+		Days: [7]string{
+			"Sonntag", "Montag", "Dienstag", "Mittwoch",
+			"Donnerstag", "Freitag", "Samstag",
+		},
+		ShortDays: [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		Weekdays:  [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		MonthYear: "January 2006",
+		LongDate:  "Monday, 2. January 2006",
+		DayOfYear: "Tag %v",
+		Week:      "Woche %v",
+		Today:     "heute",
+		Tomorrow:  "morgen",
+		Yesterday: "gestern",
+		Later:     "in %v Tagen",
+		Earlier:   "vor %v Tagen",
+		Phases: [4]string{
+			"Neumond", "Erstes Viertel", "Vollmond", "Letztes Viertel",
+		},
+		Sunrise:      "Sonnenaufgang %v",
+		Sunset:       "Sonnenuntergang %v",
+		Daylight:     "%v Tageslicht",
+		MidnightSun:  "Mitternachtssonne",
+		PolarNight:   "Polarnacht",
+		DayCount:     [2]string{"%v Tag", "%v Tage"},
+		BusinessDays: [2]string{"%v Werktag", "%v Werktage"},
+	},
+	"es": {
+		Language: "es",
+		Months: [12]string{
+			"enero", "febrero", "marzo", "abril", "mayo", "junio",
+			"julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre",
+		},
+		ShortMonths: [12]string{
+			"ene", "feb", "mar", "abr", "may", "jun",
+			"jul", "ago", "sept", "oct", "nov", "dic",
+		},
+		Days: [7]string{
+			"domingo", "lunes", "martes", "miércoles",
+			"jueves", "viernes", "sábado",
+		},
+		ShortDays: [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		Weekdays:  [7]string{"do", "lu", "ma", "mi", "ju", "vi", "sá"},
+		MonthYear: "January 2006",
+		LongDate:  "Monday, 2 de January de 2006",
+		DayOfYear: "día %v",
+		Week:      "semana %v",
+		Today:     "hoy",
+		Tomorrow:  "mañana",
+		Yesterday: "ayer",
+		Later:     "dentro de %v días",
+		Earlier:   "hace %v días",
+		Phases: [4]string{
+			"Luna nueva", "Cuarto creciente", "Luna llena", "Cuarto menguante",
+		},
+		Sunrise:      "amanecer %v",
+		Sunset:       "atardecer %v",
+		Daylight:     "%v de luz",
+		MidnightSun:  "sol de medianoche",
+		PolarNight:   "noche polar",
+		DayCount:     [2]string{"%v día", "%v días"},
+		BusinessDays: [2]string{"%v día hábil", "%v días hábiles"},
+	},
+	"fr": {
+		Language: "fr",
+		Months: [12]string{
+			"janvier", "février", "mars", "avril", "mai", "juin",
+			"juillet", "août", "septembre", "octobre", "novembre", "décembre",
+		},
+		ShortMonths: [12]string{
+			"janv.", "févr.", "mars", "avr.", "mai", "juin",
+			"juil.", "août", "sept.", "oct.", "nov.", "déc.",
+		},
+		Days: [7]string{
+			"dimanche", "lundi", "mardi", "mercredi",
+			"jeudi", "vendredi", "samedi",
+		},
+		ShortDays: [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		Weekdays:  [7]string{"di", "lu", "ma", "me", "je", "ve", "sa"},
+		MonthYear: "January 2006",
+		LongDate:  "Monday 2 January 2006",
+		DayOfYear: "jour %v",
+		Week:      "semaine %v",
+		Today:     "aujourd'hui",
+		Tomorrow:  "demain",
+		Yesterday: "hier",
+		Later:     "dans %v jours",
+		Earlier:   "il y a %v jours",
+		Phases: [4]string{
+			"Nouvelle lune", "Premier quartier", "Pleine lune", "Dernier quartier",
+		},
+		Sunrise:      "lever %v",
+		Sunset:       "coucher %v",
+		Daylight:     "%v de jour",
+		MidnightSun:  "soleil de minuit",
+		PolarNight:   "nuit polaire",
+		DayCount:     [2]string{"%v jour", "%v jours"},
+		BusinessDays: [2]string{"%v jour ouvré", "%v jours ouvrés"},
+	},
+	"it": {
+		Language: "it",
+		Months: [12]string{
+			"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno",
+			"luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre",
+		},
+		ShortMonths: [12]string{
+			"gen", "feb", "mar", "apr", "mag", "giu",
+			"lug", "ago", "set", "ott", "nov", "dic",
+		},
+		Days: [7]string{
+			"domenica", "lunedì", "martedì", "mercoledì",
+			"giovedì", "venerdì", "sabato",
+		},
+		ShortDays: [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+		Weekdays:  [7]string{"do", "lu", "ma", "me", "gi", "ve", "sa"},
+		MonthYear: "January 2006",
+		LongDate:  "Monday 2 January 2006",
+		DayOfYear: "giorno %v",
+		Week:      "settimana %v",
+		Today:     "oggi",
+		Tomorrow:  "domani",
+		Yesterday: "ieri",
+		Later:     "tra %v giorni",
+		Earlier:   "%v giorni fa",
+		Phases: [4]string{
+			"Luna nuova", "Primo quarto", "Luna piena", "Ultimo quarto",
+		},
+		Sunrise:      "alba %v",
+		Sunset:       "tramonto %v",
+		Daylight:     "%v di luce",
+		MidnightSun:  "sole di mezzanotte",
+		PolarNight:   "notte polare",
+		DayCount:     [2]string{"%v giorno", "%v giorni"},
+		BusinessDays: [2]string{"%v giorno lavorativo", "%v giorni lavorativi"},
+	},
+	"nl": {
+		Language: "nl",
+		Months: [12]string{
+			"januari", "februari", "maart", "april", "mei", "juni",
+			"juli", "augustus", "september", "oktober", "november", "december",
+		},
+		ShortMonths: [12]string{
+			"jan", "feb", "mrt", "apr", "mei", "jun",
+			"jul", "aug", "sep", "okt", "nov", "dec",
+		},
+		Days: [7]string{
+			"zondag", "maandag", "dinsdag", "woensdag",
+			"donderdag", "vrijdag", "zaterdag",
+		},
+		ShortDays: [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+		Weekdays:  [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+		MonthYear: "January 2006",
+		LongDate:  "Monday 2 January 2006",
+		DayOfYear: "dag %v",
+		Week:      "week %v",
+		Today:     "vandaag",
+		Tomorrow:  "morgen",
+		Yesterday: "gisteren",
+		Later:     "over %v dagen",
+		Earlier:   "%v dagen geleden",
+		Phases: [4]string{
+			"Nieuwe maan", "Eerste kwartier", "Volle maan", "Laatste kwartier",
+		},
+		Sunrise:      "zonsopkomst %v",
+		Sunset:       "zonsondergang %v",
+		Daylight:     "%v daglicht",
+		MidnightSun:  "middernachtzon",
+		PolarNight:   "poolnacht",
+		DayCount:     [2]string{"%v dag", "%v dagen"},
+		BusinessDays: [2]string{"%v werkdag", "%v werkdagen"},
+	},
+	"ja": {
+		Language: "ja",
+		Months: [12]string{
+			"1月", "2月", "3月", "4月", "5月", "6月",
+			"7月", "8月", "9月", "10月", "11月", "12月",
+		},
+		ShortMonths: [12]string{
+			"1月", "2月", "3月", "4月", "5月", "6月",
+			"7月", "8月", "9月", "10月", "11月", "12月",
+		},
+		Days: [7]string{
+			"日曜日", "月曜日", "火曜日", "水曜日",
+			"木曜日", "金曜日", "土曜日",
+		},
+		ShortDays: [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		Weekdays:  [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		MonthYear: "2006年January",
+		LongDate:  "2006年1月2日 Monday",
+		DayOfYear: "%v日目",
+		Week:      "第%v週",
+		Today:     "今日",
+		Tomorrow:  "明日",
+		Yesterday: "昨日",
+		Later:     "%v日後",
+		Earlier:   "%v日前",
+		Phases: [4]string{
+			"新月", "上弦", "満月", "下弦",
+		},
+		Sunrise:      "日の出 %v",
+		Sunset:       "日の入り %v",
+		Daylight:     "日照 %v",
+		MidnightSun:  "白夜",
+		PolarNight:   "極夜",
+		DayCount:     [2]string{"%v日", "%v日"},
+		BusinessDays: [2]string{"営業日%v日", "営業日%v日"},
+	},
+}
@@ -3,15 +3,22 @@
 package main
 
 import (
+	"fmt"
 	"io"
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"git.sr.ht/~kota/calendar/calendar"
+	"git.sr.ht/~kota/calendar/clock"
 	"git.sr.ht/~kota/calendar/config"
+	"git.sr.ht/~kota/calendar/date"
 	"git.sr.ht/~kota/calendar/help"
+	"git.sr.ht/~kota/calendar/locale"
+	"git.sr.ht/~kota/calendar/status"
+	"git.sr.ht/~kota/calendar/tz"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	zone "github.com/lrstanley/bubblezone"
@@ -23,21 +30,35 @@ var Version = "development version"
 // tickerMsg is a tea.Msg which should be returned with the current time.
 type tickerMsg time.Time
 
+// tickInterval is the longest time to wait between checking if the day has
+// changed. Timers are paused while a computer is suspended, so a single timer
+// set for midnight could fire hours late after resuming.
+const tickInterval = time.Minute
+
+// jumpThreshold is how far the wall clock may drift from the monotonic clock
+// between ticks before we assume the clock was changed or the computer was
+// suspended.
+const jumpThreshold = 5 * time.Second
+
 // model is the top level Bubble Tea model for the whole program.
 type model struct {
 	config   *config.Config
 	mode     mode
 	calendar calendar.Calendar
 	help     help.Help
+	status   status.Status
 	width    int
 	height   int
+	clock    clock.Clock
+	lastTick time.Time
 }
 
 // Init the model in Bubble Tea.
 func (m model) Init() tea.Cmd {
 	cmds := []tea.Cmd{
 		m.calendar.Init(),
-		fiveMinutes(),
+		m.status.Init(),
+		tick(m.clock, m.config.Location()),
 	}
 	return tea.Batch(cmds...)
 }
@@ -50,37 +71,74 @@ const (
 	modeHelp
 )
 
-// fiveMinutes starts a timer which will return a tickerMsg after five minutes.
-// This is used to update the "today" value on the calendar.
-func fiveMinutes() tea.Cmd {
-	now := time.Now()
-	tomorrow := time.Date(
+// tick starts a timer which will return a tickerMsg at the next midnight, or
+// sooner to notice if the computer was suspended. This is used to update the
+// "today" value on the calendar.
+func tick(c clock.Clock, location *time.Location) tea.Cmd {
+	return tea.Tick(nextTick(c, location), func(t time.Time) tea.Msg {
+		return tickerMsg(t)
+	})
+}
+
+// nextTick returns the time until the next tick of a clock. A frozen clock
+// never reaches midnight, so it always waits for the tickInterval rather than
+// waking again and again just before midnight.
+func nextTick(c clock.Clock, location *time.Location) time.Duration {
+	if _, ok := c.(clock.Frozen); ok {
+		return tickInterval
+	}
+	return untilTick(c.Now().In(location))
+}
+
+// untilTick returns the time until the next tick: the next midnight in the
+// location of now or tickInterval, whichever is sooner.
+func untilTick(now time.Time) time.Duration {
+	midnight := time.Date(
 		now.Year(),
 		now.Month(),
-		now.Day(),
-		now.Hour(),
-		now.Minute()+5,
-		0,
-		0,
+		now.Day()+1,
+		0, 0, 0, 0,
 		now.Location(),
 	)
-	d := tomorrow.Sub(now)
+	d := midnight.Sub(now)
+	if d > tickInterval {
+		return tickInterval
+	}
+	return d
+}
 
-	return tea.Tick(d, func(t time.Time) tea.Msg {
-		return tickerMsg(t)
-	})
+// clockJumped reports if the wall clock moved differently from the monotonic
+// clock between two ticks. This happens when the clock is changed by hand or
+// when the computer is suspended and resumed.
+func clockJumped(last, now time.Time) bool {
+	if last.IsZero() {
+		return false
+	}
+	return drifted(now.Round(0).Sub(last.Round(0)), now.Sub(last))
+}
+
+// drifted reports if the time elapsed on the wall clock differs from the time
+// elapsed on the monotonic clock by more than the jumpThreshold.
+func drifted(wall, monotonic time.Duration) bool {
+	drift := wall - monotonic
+	return drift > jumpThreshold || drift < -jumpThreshold
 }
 
 // Updates the model in the Bubble Tea update loop.
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.calendar.Prompting() {
+			break
+		}
 		switch {
 		case m.config.KeyHelp.Contains(msg.String()):
 			m.mode = modeHelp
+			m.calendar.ClearHover()
 		case m.config.KeyQuit.Contains(msg.String()):
 			if m.mode == modeHelp {
 				m.mode = modeCalendar
+				m.calendar.ClearHover()
 			} else {
 				return m, tea.Quit
 			}
@@ -90,22 +148,40 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 	case tickerMsg:
-		// Update the "today" value and kick off another timer.
-		m.calendar.SetToday(time.Now())
-		return m, fiveMinutes()
+		// Update the "today" value if the day has changed and kick off another
+		// timer. The clock is asked rather than using the time of the tick so
+		// a frozen clock stays frozen.
+		now := m.clock.Now().In(m.config.Location())
+		var cmd tea.Cmd
+		if date.Days(m.calendar.Today(), now) != 0 ||
+			clockJumped(m.lastTick, time.Time(msg)) {
+			m.calendar, cmd = m.calendar.Rollover(now)
+		}
+		m.lastTick = time.Time(msg)
+		return m, tea.Batch(cmd, tick(m.clock, m.config.Location()))
 	}
 	return m.propagate(msg)
 }
 
 // propagate an update to all children.
 func (m model) propagate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
 	var c tea.Cmd
+	m.status, c = m.status.Update(msg)
+	cmds = append(cmds, c)
+
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		// Leave room for the status line.
+		size.Height--
+		msg = size
+	}
 	if m.mode == modeHelp {
 		m.help, c = m.help.Update(msg)
 	} else {
 		m.calendar, c = m.calendar.Update(msg)
 	}
-	return m, c
+	cmds = append(cmds, c)
+	return m, tea.Batch(cmds...)
 }
 
 // View renders the model in its current state.
@@ -121,16 +197,53 @@ func (m model) View() string {
 		)
 	}
 
-	// Display the calendar.
-	return zone.Scan(lipgloss.Place(
-		m.width,
-		m.height,
-		lipgloss.Center,
-		lipgloss.Center,
-		m.calendar.View(),
+	// Display the calendar with the status line below it.
+	return zone.Scan(lipgloss.JoinVertical(
+		lipgloss.Left,
+		lipgloss.Place(
+			m.width,
+			m.height-1,
+			lipgloss.Center,
+			lipgloss.Center,
+			m.calendar.View(),
+		),
+		m.status.View(m.calendar.Details()),
 	))
 }
 
+// parseToday removes a --today DATE or --today=DATE flag from the program's
+// arguments and returns a clock frozen at that date, or the system clock if
+// the flag is not given.
+func parseToday(
+	args []string,
+	location *time.Location,
+) ([]string, clock.Clock, error) {
+	var rest []string
+	var clk clock.Clock = clock.System{}
+	for i := 0; i < len(args); i++ {
+		var value string
+		switch {
+		case args[i] == "--today":
+			if i+1 == len(args) {
+				return nil, nil, fmt.Errorf("--today needs a date")
+			}
+			i++
+			value = args[i]
+		case strings.HasPrefix(args[i], "--today="):
+			value = strings.TrimPrefix(args[i], "--today=")
+		default:
+			rest = append(rest, args[i])
+			continue
+		}
+		frozen, err := clock.Parse(value, location)
+		if err != nil {
+			return nil, nil, fmt.Errorf("--today: %v", err)
+		}
+		clk = frozen
+	}
+	return rest, clk, nil
+}
+
 // parseArgs reads the program's arguments to parse a starting selected time.
 func parseArgs(args []string, now time.Time) time.Time {
 	switch len(args) {
@@ -139,7 +252,11 @@ func parseArgs(args []string, now time.Time) time.Time {
 		return now
 	case 2:
 		// Argument is either "day", "timestamp", or "monthname".
-		timestamp, err := time.Parse("2006-01-02", args[1])
+		timestamp, err := time.ParseInLocation(
+			"2006-01-02",
+			args[1],
+			now.Location(),
+		)
 		if err == nil {
 			return timestamp
 		}
@@ -223,16 +340,39 @@ func main() {
 		log.Fatalf("failed to load config: %v\n", err)
 	}
 
-	selected := parseArgs(os.Args, time.Now())
+	args, clk, err := parseToday(os.Args, conf.Location())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if len(args) > 1 {
+		if cmd, ok := commands[args[1]]; ok {
+			if err := cmd(args[2:], conf, clk, os.Stdout); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	zones, err := tz.Load(conf.Zones, clk)
+	if err != nil {
+		log.Fatalf("failed to load config: %v\n", err)
+	}
+
+	selected := parseArgs(args, clk.Now().In(conf.Location()))
 	zone.NewGlobal()
 	p := tea.NewProgram(
 		model{
-			calendar: calendar.New(selected, conf),
-			help:     help.New(Version),
+			calendar: calendar.New(selected, conf, clk),
+			help:     help.New(Version, locale.Get(conf.Locale).Language),
+			status:   status.New(conf, zones, clk),
 			config:   conf,
+			clock:    clk,
 		},
 		tea.WithAltScreen(),
-		tea.WithMouseCellMotion(),
+		tea.WithMouseAllMotion(),
 	)
 	if _, err := p.Run(); err != nil {
 		log.Fatalf("calendar has crashed: %v\n", err)
@@ -6,6 +6,11 @@ import (
 	"reflect"
 	"testing"
 	"time"
+
+	"git.sr.ht/~kota/calendar/calendar"
+	"git.sr.ht/~kota/calendar/clock"
+	"git.sr.ht/~kota/calendar/config"
+	"git.sr.ht/~kota/calendar/date"
 )
 
 func TestParseArgs(t *testing.T) {
@@ -142,3 +147,155 @@ func TestParseArgs(t *testing.T) {
 		}
 	}
 }
+
+func TestUntilTick(t *testing.T) {
+	auckland, err := time.LoadLocation("Pacific/Auckland")
+	if err != nil {
+		t.Fatalf("failed loading Pacific/Auckland timezone: %v", err)
+	}
+
+	type test struct {
+		now  time.Time
+		want time.Duration
+	}
+
+	tests := []test{
+		{
+			now:  time.Date(2023, time.January, 2, 12, 0, 0, 0, time.UTC),
+			want: tickInterval,
+		},
+		{
+			now:  time.Date(2023, time.January, 2, 23, 59, 30, 0, time.UTC),
+			want: 30 * time.Second,
+		},
+		{
+			// Daylight saving time ends at 03:00 on this day, which is still
+			// only 30 seconds before midnight.
+			now:  time.Date(2023, time.April, 1, 23, 59, 30, 0, auckland),
+			want: 30 * time.Second,
+		},
+	}
+
+	for _, tc := range tests {
+		if got := untilTick(tc.now); got != tc.want {
+			t.Fatalf("expected: %v, got: %v", tc.want, got)
+		}
+	}
+}
+
+// ticking is a clock which isn't frozen, though it always reads the same time.
+type ticking time.Time
+
+func (t ticking) Now() time.Time {
+	return time.Time(t)
+}
+
+func TestNextTick(t *testing.T) {
+	now := time.Date(2023, time.January, 2, 23, 59, 59, 0, time.UTC)
+	if got := nextTick(ticking(now), time.UTC); got != time.Second {
+		t.Fatalf("expected: %v, got: %v", time.Second, got)
+	}
+	// A frozen clock would otherwise wake every second just before midnight.
+	if got := nextTick(clock.Frozen(now), time.UTC); got != tickInterval {
+		t.Fatalf("expected: %v, got: %v", tickInterval, got)
+	}
+}
+
+func TestClockJumped(t *testing.T) {
+	last := time.Now()
+	if clockJumped(time.Time{}, last) {
+		t.Fatalf("expected no jump from the zero time")
+	}
+	if clockJumped(last, last.Add(time.Minute)) {
+		t.Fatalf("expected no jump when both clocks advance together")
+	}
+
+	// The time package cannot move only the wall clock of a time while
+	// keeping its monotonic reading, so jumps are checked by the elapsed time
+	// on each clock.
+	type test struct {
+		description string
+		wall        time.Duration
+		monotonic   time.Duration
+		want        bool
+	}
+
+	tests := []test{
+		{description: "ticking", wall: time.Minute, monotonic: time.Minute},
+		{
+			description: "small adjustment",
+			wall:        time.Minute + 2*time.Second,
+			monotonic:   time.Minute,
+		},
+		{
+			description: "suspended for an hour",
+			wall:        time.Hour + time.Minute,
+			monotonic:   time.Minute,
+			want:        true,
+		},
+		{
+			description: "clock set back",
+			wall:        -time.Hour,
+			monotonic:   time.Minute,
+			want:        true,
+		},
+	}
+
+	for _, tc := range tests {
+		if got := drifted(tc.wall, tc.monotonic); got != tc.want {
+			t.Fatalf("%v: want: %v, got: %v", tc.description, tc.want, got)
+		}
+	}
+}
+
+func TestParseToday(t *testing.T) {
+	want := time.Date(2025, time.December, 25, 0, 0, 0, 0, time.UTC)
+	inputs := [][]string{
+		{"calendar", "--today", "2025-12-25", "2026-01-01"},
+		{"calendar", "2026-01-01", "--today=2025-12-25"},
+	}
+	for _, input := range inputs {
+		args, clk, err := parseToday(input, time.UTC)
+		if err != nil {
+			t.Fatalf("failed parsing %q: %v", input, err)
+		}
+		if rest := []string{"calendar", "2026-01-01"}; !reflect.DeepEqual(args, rest) {
+			t.Fatalf("want arguments: %q, got: %q", rest, args)
+		}
+		if got := clk.Now(); !got.Equal(want) {
+			t.Fatalf("want today: %v, got: %v", want, got)
+		}
+	}
+
+	if _, clk, _ := parseToday([]string{"calendar"}, time.UTC); clk != (clock.System{}) {
+		t.Fatalf("want the system clock without the flag, got: %v", clk)
+	}
+	for _, input := range [][]string{
+		{"calendar", "--today"},
+		{"calendar", "--today", "tomorrow"},
+	} {
+		if _, _, err := parseToday(input, time.UTC); err == nil {
+			t.Fatalf("expected an error parsing %q", input)
+		}
+	}
+}
+
+func TestFrozenTick(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	conf := config.Default()
+	frozen := clock.Frozen(
+		time.Date(2025, time.December, 25, 9, 30, 0, 0, conf.Location()),
+	)
+	m := model{
+		config:   conf,
+		clock:    frozen,
+		calendar: calendar.New(frozen.Now(), conf, frozen),
+	}
+
+	// A tick from the real clock does not move today away from the frozen
+	// clock.
+	next, _ := m.Update(tickerMsg(time.Now()))
+	if got := next.(model).calendar.Today(); date.Days(got, frozen.Now()) != 0 {
+		t.Fatalf("want today to stay %v, got: %v", frozen.Now(), got)
+	}
+}
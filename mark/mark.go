@@ -0,0 +1,92 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package mark
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	gap "github.com/muesli/go-app-paths"
+)
+
+// Marks maps a single letter name to a saved date.
+type Marks map[string]time.Time
+
+// Path returns the path of the marks file in the user's data directory.
+func Path() (string, error) {
+	scope := gap.NewScope(gap.User, "calendar")
+	return scope.DataPath("marks")
+}
+
+// Valid reports if a string can be used as the name of a mark.
+func Valid(name string) bool {
+	if len(name) != 1 {
+		return false
+	}
+	r := name[0]
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+// Load reads a marks file. A missing file is treated as an empty file.
+//
+// Each line in the file is a mark name, a space, and a date in the format
+// 2006-01-02.
+func Load(path string, location *time.Location) (Marks, error) {
+	marks := make(Marks)
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return marks, nil
+		}
+		return marks, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for i := 1; scanner.Scan(); i++ {
+		text := scanner.Text()
+		if text == "" {
+			continue
+		}
+
+		name, day, ok := strings.Cut(text, " ")
+		if !ok || !Valid(name) {
+			return marks, fmt.Errorf("line %v: invalid mark", i)
+		}
+		t, err := time.ParseInLocation("2006-01-02", day, location)
+		if err != nil {
+			return marks, fmt.Errorf("line %v: invalid date %v: %v", i, day, err)
+		}
+		marks[name] = t
+	}
+	return marks, scanner.Err()
+}
+
+// Save writes the marks to a file, creating its directory if needed.
+func (ms Marks) Save(path string) error {
+	names := make([]string, 0, len(ms))
+	for name := range ms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteString(" ")
+		b.WriteString(ms[name].Format("2006-01-02"))
+		b.WriteString("\n")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
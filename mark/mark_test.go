@@ -0,0 +1,50 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package mark
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calendar", "marks")
+	want := Marks{
+		"a": time.Date(2023, time.January, 6, 0, 0, 0, 0, time.UTC),
+		"R": time.Date(2022, time.November, 30, 0, 0, 0, 0, time.UTC),
+	}
+	if err := want.Save(path); err != nil {
+		t.Fatalf("failed saving marks: %v", err)
+	}
+
+	got, err := Load(path, time.UTC)
+	if err != nil {
+		t.Fatalf("failed loading marks: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want: %v, got: %v", want, got)
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	got, err := Load(filepath.Join(t.TempDir(), "marks"), time.UTC)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("want no marks, got: %v", got)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "marks")
+	if err := os.WriteFile(path, []byte("ab 2023-01-06\n"), 0o644); err != nil {
+		t.Fatalf("failed writing marks: %v", err)
+	}
+	if _, err := Load(path, time.UTC); err == nil {
+		t.Fatalf("expected an error for an invalid mark name")
+	}
+}
@@ -0,0 +1,136 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package month
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"git.sr.ht/~kota/calendar/config"
+	"git.sr.ht/~kota/calendar/index"
+	"git.sr.ht/~kota/calendar/note"
+)
+
+// Cache holds the styling of days shared by every month, so resizing or
+// moving between months does not read every note again. A day is styled
+// again when its note's modification time or size changes, or after the
+// cache is cleared. It is safe for concurrent use by months loading their
+// styled days at the same time. A nil Cache caches nothing.
+type Cache struct {
+	mu   sync.Mutex
+	days map[string]cachedDay
+	// generation counts how many times the cache has been cleared, so days
+	// styled before a clear are not cached after it.
+	generation int
+	// index is the note index used to find keywords without reading notes,
+	// or nil until it has loaded.
+	index *index.Index
+}
+
+// cachedDay is the styling of a day and the version of its note it was
+// computed from.
+type cachedDay struct {
+	stamp  stamp
+	style  config.Style
+	styled bool
+	span   span
+}
+
+// stamp identifies a version of a note by its modification time and size. A
+// missing note has a zero stamp.
+type stamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// equal reports if two stamps are for the same version of a note.
+func (s stamp) equal(o stamp) bool {
+	return s.size == o.size && s.modTime.Equal(o.modTime)
+}
+
+// NewCache creates an empty cache.
+func NewCache() *Cache {
+	return &Cache{days: make(map[string]cachedDay)}
+}
+
+// Clear forgets the styling of every day. This must be called when something
+// other than the notes changes how days are styled, such as the holidays.
+func (c *Cache) Clear() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.days = make(map[string]cachedDay)
+	c.generation++
+}
+
+// SetIndex sets the note index, which days are styled from instead of reading
+// their notes when it is up to date with them.
+func (c *Cache) SetIndex(ix *index.Index) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.index = ix
+}
+
+// noteIndex returns the note index, or nil if it has not loaded.
+func (c *Cache) noteIndex() *index.Index {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.index
+}
+
+// current returns the generation of the cache, which is passed to put.
+func (c *Cache) current() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// get returns the styling of a day if it was cached from the same version of
+// its note.
+func (c *Cache) get(key string, s stamp) (cachedDay, bool) {
+	if c == nil {
+		return cachedDay{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	d, ok := c.days[key]
+	if !ok || !d.stamp.equal(s) {
+		return cachedDay{}, false
+	}
+	return d, true
+}
+
+// put caches the styling of a day, unless the cache has been cleared since the
+// given generation.
+func (c *Cache) put(key string, d cachedDay, generation int) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation == c.generation {
+		c.days[key] = d
+	}
+}
+
+// noteStamp stats the note for time t. Empty notes and directories have a
+// zero stamp, the same as missing notes.
+func noteStamp(t time.Time, dir string) stamp {
+	info, err := os.Stat(note.Path(t, dir))
+	if err != nil || info.IsDir() || info.Size() == 0 {
+		return stamp{}
+	}
+	return stamp{modTime: info.ModTime(), size: info.Size()}
+}
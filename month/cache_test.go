@@ -0,0 +1,105 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package month
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"git.sr.ht/~kota/calendar/config"
+	"git.sr.ht/~kota/calendar/holiday"
+	"git.sr.ht/~kota/calendar/index"
+	"git.sr.ht/~kota/calendar/keyword"
+	"git.sr.ht/~kota/calendar/note"
+)
+
+func TestCache(t *testing.T) {
+	conf := config.Default()
+	conf.NoteDir = t.TempDir()
+	conf.Keywords = keyword.Keywords{{Keyword: "dentist", Color: "5"}}
+	day := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC)
+	path := note.Path(day, conf.NoteDir)
+	if err := os.WriteFile(path, []byte("dentist at 9\n"), 0o644); err != nil {
+		t.Fatalf("failed writing note: %v", err)
+	}
+
+	cache := NewCache()
+	styled := func(hs holiday.Holidays) (config.Style, bool) {
+		m := New(day, day, day, LayoutColumn, hs, cache, conf)
+		msg := m.Init()().(styledDaysMsg)
+		return msg.styledDays.Match(day)
+	}
+
+	if s, ok := styled(nil); !ok || s.Color != "5" {
+		t.Fatalf("want the keyword's style, got: %+v", s)
+	}
+
+	// Without a change to the note the cached style is used, even though the
+	// holidays given to this month differ.
+	piDay := holiday.Holidays{{Date: "0000-03-14", Color: "1"}}
+	if s, ok := styled(piDay); !ok || s.Color != "5" {
+		t.Fatalf("want the cached style, got: %+v", s)
+	}
+
+	// Changing the note restyles the day.
+	later := time.Now().Add(time.Hour)
+	if err := os.WriteFile(path, []byte("nothing\n"), 0o644); err != nil {
+		t.Fatalf("failed writing note: %v", err)
+	}
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatalf("failed changing note times: %v", err)
+	}
+	if s, ok := styled(nil); ok {
+		t.Fatalf("want no style after the note changed, got: %+v", s)
+	}
+
+	// Clearing the cache restyles the day with new holidays.
+	cache.Clear()
+	if s, ok := styled(piDay); !ok || s.Color != "1" {
+		t.Fatalf("want the holiday's style after clearing, got: %+v", s)
+	}
+}
+
+func TestCacheIndex(t *testing.T) {
+	conf := config.Default()
+	conf.NoteDir = t.TempDir()
+	conf.Keywords = keyword.Keywords{{Keyword: "dentist", Color: "5"}}
+	day := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC)
+	path := note.Path(day, conf.NoteDir)
+	if err := os.WriteFile(path, []byte("dentist at 9\n"), 0o644); err != nil {
+		t.Fatalf("failed writing note: %v", err)
+	}
+	ix := index.New()
+	if _, err := ix.Update(conf.NoteDir, conf.Keywords); err != nil {
+		t.Fatalf("failed updating index: %v", err)
+	}
+
+	styled := func(cache *Cache) (config.Style, bool) {
+		m := New(day, day, day, LayoutColumn, nil, cache, conf)
+		msg := m.Init()().(styledDaysMsg)
+		return msg.styledDays.Match(day)
+	}
+
+	// The keywords are taken from the index rather than the note, so a
+	// keyword missing from the index is not styled.
+	n := ix.Notes["2025-03-14"]
+	n.Matches = nil
+	ix.Notes["2025-03-14"] = n
+	cache := NewCache()
+	cache.SetIndex(ix)
+	if s, ok := styled(cache); ok {
+		t.Fatalf("want the style from the index, got: %+v", s)
+	}
+
+	// An index which is out of date with the note is not used.
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatalf("failed changing note times: %v", err)
+	}
+	cache = NewCache()
+	cache.SetIndex(ix)
+	if s, ok := styled(cache); !ok || s.Color != "5" {
+		t.Fatalf("want the keyword's style from the note, got: %+v", s)
+	}
+}
@@ -12,9 +12,11 @@ import (
 	"git.sr.ht/~kota/calendar/config"
 	"git.sr.ht/~kota/calendar/date"
 	"git.sr.ht/~kota/calendar/holiday"
+	"git.sr.ht/~kota/calendar/locale"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	zone "github.com/lrstanley/bubblezone"
+	"github.com/muesli/reflow/truncate"
 )
 
 const (
@@ -43,9 +45,14 @@ type Month struct {
 	date       time.Time
 	today      time.Time
 	selected   time.Time
+	rangeStart time.Time
+	rangeEnd   time.Time
 	styledDays styledDays
+	spans      map[string]span
 	holidays   holiday.Holidays
+	cache      *Cache
 	config     *config.Config
+	locale     locale.Locale
 	id         string
 	layout     Layout
 	isFocused  bool
@@ -53,11 +60,13 @@ type Month struct {
 
 // New creates a new month model.
 // The list of styledDays is not calculated on creation. The Init method must
-// be called to parse and load these concurrently.
+// be called to parse and load these concurrently. The cache is shared with the
+// other months and may be nil.
 func New(
 	date, today, selected time.Time,
 	layout Layout,
 	holidays holiday.Holidays,
+	cache *Cache,
 	conf *config.Config,
 ) Month {
 	return Month{
@@ -67,14 +76,19 @@ func New(
 		selected: selected,
 		layout:   layout,
 		holidays: holidays,
+		cache:    cache,
 		config:   conf,
+		locale:   locale.Get(conf.Locale),
 	}
 }
 
 // Init the month in Bubble Tea.
 // Reads holidays and keywords to build out a list of styledDays.
 func (m Month) Init() tea.Cmd {
-	return m.loadStyledDays
+	generation := m.cache.current()
+	return func() tea.Msg {
+		return m.loadStyledDays(generation)
+	}
 }
 
 // Updates the month in the Bubble Tea update loop.
@@ -83,50 +97,37 @@ func (m Month) Update(msg tea.Msg) (Month, tea.Cmd) {
 	case styledDaysMsg:
 		if date.SameMonth(m.date, msg.month) {
 			m.styledDays = msg.styledDays
+			m.spans = msg.spans
 		}
 	case tea.KeyMsg:
 		if !m.isFocused {
 			return m, nil
 		}
 		m.move(msg)
-	case tea.MouseMsg:
-		switch msg.Type {
-		case tea.MouseWheelUp:
-			if m.isFocused {
-				m.selected = m.selected.AddDate(0, 0, -7)
-			}
-		case tea.MouseWheelDown:
-			if m.isFocused {
-				m.selected = m.selected.AddDate(0, 0, 7)
-			}
-		case tea.MouseLeft:
-			last := date.LastDay(m.date)
-			for day := 1; day <= last.Day(); day++ {
-				if zone.Get(m.id + "-" + strconv.Itoa(day)).InBounds(msg) {
-					t, err := time.ParseInLocation(
-						"2006-01",
-						m.id,
-						m.date.Location(),
-					)
-					if err != nil {
-						return m, nil
-					}
-
-					year := t.Year()
-					month := t.Month()
-					m.selected = time.Date(
-						year, month, day, 0, 0, 0, 0,
-						m.date.Location(),
-					)
-					break
-				}
-			}
-		}
 	}
 
 	return m, nil
 }
 
+// DayAt returns the day under the mouse, if any.
+func (m Month) DayAt(msg tea.MouseMsg) (time.Time, bool) {
+	last := date.LastDay(m.date)
+	for day := 1; day <= last.Day(); day++ {
+		if zone.Get(m.id + "-" + strconv.Itoa(day)).InBounds(msg) {
+			return time.Date(
+				m.date.Year(), m.date.Month(), day, 0, 0, 0, 0,
+				m.date.Location(),
+			), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// HeadingAt reports if the mouse is over the month's heading.
+func (m Month) HeadingAt(msg tea.MouseMsg) bool {
+	return zone.Get(m.id + "-heading").InBounds(msg)
+}
+
 // Date returns this month's date.
 func (m Month) Date() time.Time {
 	return m.date
@@ -158,9 +159,32 @@ func (m *Month) SetToday(t time.Time) {
 	m.today = t
 }
 
+// SetHolidays replaces the holidays shown in the month. The shared cache must be
+// cleared and the Init method called again to restyle the days.
+func (m *Month) SetHolidays(hs holiday.Holidays) {
+	m.holidays = hs
+}
+
+// SetRange sets the range of highlighted days. Both start and end are
+// included in the range. Zero times clear the range.
+func (m *Month) SetRange(start, end time.Time) {
+	m.rangeStart = start
+	m.rangeEnd = end
+}
+
+// inRange reports if a given time is within the highlighted range.
+func (m Month) inRange(t time.Time) bool {
+	if m.rangeStart.IsZero() || m.rangeEnd.IsZero() {
+		return false
+	}
+	d := t.Format("2006-01-02")
+	return d >= m.rangeStart.Format("2006-01-02") &&
+		d <= m.rangeEnd.Format("2006-01-02")
+}
+
 // View renders the month in its current state.
 func (m Month) View() string {
-	h := headingStyle.Render(m.heading())
+	h := zone.Mark(m.id+"-heading", headingStyle.Render(m.heading()))
 	g := gridStyle.Render(m.grid())
 
 	return monthstyle.Render(lipgloss.JoinVertical(lipgloss.Top, h, g))
@@ -170,13 +194,14 @@ func (m Month) View() string {
 // below it.
 func (m Month) heading() string {
 	var heading strings.Builder
-	heading.WriteString(m.date.Month().String())
+	title := m.locale.Months[m.date.Month()-1]
 	if m.layout == LayoutColumn {
-		heading.WriteString(" ")
-		heading.WriteString(strconv.Itoa(m.date.Year()))
+		title = m.locale.Format(m.date, m.locale.MonthYear)
 	}
+	// Long or wide month names are cut to fit rather than wrapping.
+	heading.WriteString(truncate.String(title, MonthWidth))
 	heading.WriteString("\n")
-	heading.WriteString("Su Mo Tu We Th Fr Sa")
+	heading.WriteString(m.locale.WeekdayHeading())
 
 	style := headingStyle.Copy()
 	if !date.SameMonth(m.date, m.selected) {
@@ -212,25 +237,42 @@ func (m Month) grid() string {
 			)
 		}
 		// Render styled days.
-		if s, ok := m.styledDays.Match(time.Date(
+		t := time.Date(
 			m.date.Year(), m.date.Month(), i, 0, 0, 0, 0,
 			m.date.Location(),
-		)); ok {
+		)
+		// Join the days of a holiday range with an underline, but only to a
+		// next day on the same row of this month.
+		rowEnd := (i+int(first.Weekday()))%7 == 0
+		gap := " "
+		if s, ok := m.styledDays.Match(t); ok {
 			day = s.Export(day.Copy())
+			switch m.spans[t.Format("2006-01-02")] {
+			case spanStart, spanMiddle:
+				day = day.Copy().Underline(true)
+				if i < last.Day() && !rowEnd {
+					gap = s.Export(lipgloss.NewStyle()).Underline(true).Render(" ")
+				}
+			case spanEnd:
+				day = day.Copy().Underline(true)
+			}
+		}
+		// Render the selected range.
+		if m.inRange(t) {
+			day = m.config.RangeStyle.Export(day.Copy())
 		}
 		// Render today.
 		if date.SameMonth(m.date, m.today) && i == m.today.Day() {
 			day = m.config.TodayStyle.Export(day.Copy())
 		}
-		b.WriteString(
-			day.Render(zone.Mark(
-				m.id+"-"+strconv.Itoa(i),
-				fmt.Sprintf("%2.d", i),
-			)),
-		)
-		b.WriteString(" ")
-		if (i+int(first.Weekday()))%7 == 0 {
-			b.WriteString("\n")
+		b.WriteString(zone.Mark(
+			m.id+"-"+strconv.Itoa(i),
+			day.Render(fmt.Sprintf("%2.d", i)),
+		))
+		if rowEnd {
+			b.WriteString(" \n")
+		} else {
+			b.WriteString(gap)
 		}
 	}
 	return b.String()
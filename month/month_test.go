@@ -0,0 +1,80 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package month
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"git.sr.ht/~kota/calendar/config"
+	"git.sr.ht/~kota/calendar/holiday"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/muesli/termenv"
+)
+
+func TestGridSpanGap(t *testing.T) {
+	lipgloss.SetColorProfile(termenv.ANSI)
+	zone.NewGlobal()
+	conf := config.Default()
+	conf.NoteDir = t.TempDir()
+	hs := holiday.Holidays{
+		{Date: "2025-03-14", End: "2025-03-17", Color: "1"},
+		{Date: "2025-03-30", End: "2025-04-02", Color: "1"},
+	}
+	march := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	other := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	m := New(march, other, other, LayoutColumn, hs, nil, conf)
+	m, _ = m.Update(m.Init()())
+	grid := zone.Scan(m.grid())
+
+	// The ranges are joined between the 14th and 15th, the 16th and 17th,
+	// and the 30th and 31st, but not from the end of a row to the next or
+	// from the end of the month.
+	gap := config.Style{Color: "1"}.Export(lipgloss.NewStyle()).
+		Underline(true).
+		Render(" ")
+	if n := strings.Count(grid, gap); n != 3 {
+		t.Fatalf("want 3 underlined gaps, got: %v in %q", n, grid)
+	}
+	if !strings.HasSuffix(grid, "1\x1b[0m ") {
+		t.Fatalf("want no underline after the last day, got: %q", grid)
+	}
+}
+
+func TestInRange(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2025, time.March, d, 0, 0, 0, 0, time.UTC)
+	}
+	m := New(day(1), day(1), day(1), LayoutColumn, nil, nil, config.Default())
+	if m.inRange(day(14)) {
+		t.Fatalf("expected no range before one is set")
+	}
+
+	// The range includes both ends, whatever the time of day.
+	m.SetRange(day(10), day(14))
+	type test struct {
+		t    time.Time
+		want bool
+	}
+
+	tests := []test{
+		{t: day(9), want: false},
+		{t: day(10), want: true},
+		{t: day(12).Add(23 * time.Hour), want: true},
+		{t: day(14).Add(9 * time.Hour), want: true},
+		{t: day(15), want: false},
+	}
+
+	for _, tc := range tests {
+		if got := m.inRange(tc.t); got != tc.want {
+			t.Fatalf("%v: want: %v, got: %v", tc.t, tc.want, got)
+		}
+	}
+
+	m.SetRange(time.Time{}, time.Time{})
+	if m.inRange(day(12)) {
+		t.Fatalf("expected clearing the range to highlight nothing")
+	}
+}
@@ -1,13 +1,13 @@
 package month
 
 import (
-	"errors"
-	"io/fs"
 	"os"
 	"time"
 
+	"git.sr.ht/~kota/calendar/astro"
 	"git.sr.ht/~kota/calendar/config"
 	"git.sr.ht/~kota/calendar/date"
+	"git.sr.ht/~kota/calendar/holiday"
 	"git.sr.ht/~kota/calendar/note"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -30,51 +30,145 @@ func (sd styledDays) Match(t time.Time) (config.Style, bool) {
 	return config.Style{}, false
 }
 
+// span is the part of a holiday covering a range of days which a day is in.
+type span uint8
+
+const (
+	spanNone span = iota
+	spanStart
+	spanMiddle
+	spanEnd
+)
+
+// spanOf returns the part of a holiday range time t is in.
+func spanOf(h holiday.Holiday, t time.Time) span {
+	prev := h.Covers(t.AddDate(0, 0, -1))
+	next := h.Covers(t.AddDate(0, 0, 1))
+	switch {
+	case !prev && next:
+		return spanStart
+	case prev && next:
+		return spanMiddle
+	case prev && !next:
+		return spanEnd
+	}
+	return spanNone
+}
+
 type styledDaysMsg struct {
 	month      time.Time
 	styledDays styledDays
+	spans      map[string]span
 }
 
 // loadStyledDays reads every note file for the given Month to create a tea.Msg
 // with days that should be styled differently (matching a keyword, holiday,
-// etc).
-func (m Month) loadStyledDays() tea.Msg {
+// etc). Days whose notes have not changed since they were last styled are
+// taken from the cache, which is only added to if it is still the same
+// generation as when the month was initialized.
+func (m Month) loadStyledDays(generation int) tea.Msg {
 	var msg styledDaysMsg
 	msg.month = m.date
 
 	sd := make(styledDays)
+	spans := make(map[string]span)
+	// Notes are only checked if their existence or content is styled.
+	checkNotes := !m.config.NotedStyle.Blank() || len(m.config.Keywords) != 0
 	last := date.LastDay(m.date)
 	for i := 1; i <= last.Day(); i++ {
 		t := time.Date(m.date.Year(), m.date.Month(),
 			i, 0, 0, 0, 0,
 			m.date.Location())
-		// Process noted days.
-		if !m.config.NotedStyle.Blank() {
-			if note.Exists(t, m.config.NoteDir) {
-				sd[t.Format("2006-01-02")] = m.config.NotedStyle
-			}
+		key := t.Format("2006-01-02")
+
+		var s stamp
+		if checkNotes {
+			s = noteStamp(t, m.config.NoteDir)
+		}
+		d, ok := m.cache.get(key, s)
+		if !ok {
+			d = m.styleDay(t, s)
+			m.cache.put(key, d, generation)
 		}
+		if d.styled {
+			sd[key] = d.style
+		}
+		if d.span != spanNone {
+			spans[key] = d.span
+		}
+	}
+
+	msg.styledDays = sd
+	msg.spans = spans
+	return msg
+}
 
-		// Process holidays.
-		if h, ok := m.holidays.Match(t); ok {
-			sd[t.Format("2006-01-02")] = config.Style{Color: h.Color}
+// styleDay works out the styling of a day whose note has the given stamp.
+func (m Month) styleDay(t time.Time, s stamp) cachedDay {
+	d := cachedDay{stamp: s}
+	set := func(style config.Style) {
+		d.style = style
+		d.styled = true
+	}
+
+	// Process noted days.
+	if !m.config.NotedStyle.Blank() && s.size > 0 {
+		set(m.config.NotedStyle)
+	}
+
+	// Process moon phases.
+	switch astro.MoonPhase(t) {
+	case astro.NewMoon:
+		if !m.config.NewMoonStyle.Blank() {
+			set(m.config.NewMoonStyle)
+		}
+	case astro.FullMoon:
+		if !m.config.FullMoonStyle.Blank() {
+			set(m.config.FullMoonStyle)
 		}
+	}
 
-		// Process keywords.
-		if len(m.config.Keywords) != 0 {
-			path := note.Path(t, m.config.NoteDir)
-			f, err := os.Open(path)
-			if err != nil && !errors.Is(err, fs.ErrNotExist) {
-				continue
-			}
-			defer f.Close()
+	// Process holidays. The first has precedence over the others falling on
+	// the same day, but any of them may cover a range.
+	matched := m.holidays.MatchAll(t)
+	if len(matched) > 0 {
+		h := matched[0]
+		set(config.Style{
+			Color:      h.Color,
+			Background: h.Background,
+			Bold:       h.Bold,
+			Italic:     h.Italic,
+		})
+	}
+	for _, h := range matched {
+		if h.End != "" {
+			d.span = spanOf(h, t)
+			break
+		}
+	}
 
-			if k, ok := m.config.Keywords.Match(f); ok {
-				sd[t.Format("2006-01-02")] = config.Style{Color: k.Color}
+	// Process keywords, from the note index if it has the same version of the
+	// note.
+	if len(m.config.Keywords) != 0 && s.size > 0 {
+		if ix := m.cache.noteIndex(); ix != nil {
+			n, ok := ix.Note(t)
+			if ok && n.Size == s.size && n.ModTime.Equal(s.modTime) {
+				if k, _, ok := n.Match(m.config.Keywords); ok {
+					set(config.Style{Color: k.Color})
+				}
+				return d
 			}
 		}
-	}
 
-	msg.styledDays = sd
-	return msg
+		f, err := os.Open(note.Path(t, m.config.NoteDir))
+		if err != nil {
+			return d
+		}
+		defer f.Close()
+
+		if k, ok := m.config.Keywords.Match(f); ok {
+			set(config.Style{Color: k.Color})
+		}
+	}
+	return d
 }
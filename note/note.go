@@ -6,6 +6,8 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -44,10 +46,79 @@ func Exists(t time.Time, dir string) bool {
 // errors will return the error string itself (which is meant to be displayed
 // to the user).
 func Load(t time.Time, dir string) string {
+	s, err := Read(t, dir)
+	if err != nil {
+		return err.Error()
+	}
+	return s
+}
+
+// Read reads a note file for a given time.
+//
+// If the file is missing it is simply treated as an empty file.
+func Read(t time.Time, dir string) (string, error) {
 	path := Path(t, dir)
 	data, err := os.ReadFile(path)
 	if err != nil && !errors.Is(err, fs.ErrNotExist) {
-		data = []byte(err.Error())
+		return "", err
+	}
+	return string(data), nil
+}
+
+// LoadRange reads every note from the first time to the last time, inclusive,
+// and joins them together. Each note is preceded by a heading with its date.
+// Days without a note are skipped.
+func LoadRange(first, last time.Time, dir string) string {
+	var b strings.Builder
+	for t := first; !t.After(last); t = t.AddDate(0, 0, 1) {
+		if !Exists(t, dir) {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n\n")
+		}
+		b.WriteString("# ")
+		b.WriteString(t.Format("2006-01-02"))
+		b.WriteString("\n\n")
+		b.WriteString(strings.TrimSpace(Load(t, dir)))
+	}
+	return b.String()
+}
+
+// Dates lists the days which have a note in ascending order. Only one directory
+// read is needed, so this is far faster than checking each day with Exists.
+// Empty notes are counted as not existing.
+func Dates(dir string, location *time.Location) ([]time.Time, error) {
+	entries, err := os.ReadDir(os.ExpandEnv(dir))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var dates []time.Time
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".md" {
+			continue
+		}
+		t, err := time.ParseInLocation(
+			"2006-01-02",
+			strings.TrimSuffix(name, ".md"),
+			location,
+		)
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || info.Size() == 0 {
+			continue
+		}
+		dates = append(dates, t)
 	}
-	return string(data)
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+	return dates, nil
 }
@@ -0,0 +1,40 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package note
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDates(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"2023-01-06.md":  "Dentist.",
+		"2022-12-25.md":  "Christmas.",
+		"2023-01-07.md":  "",
+		"notes.md":       "Not a day.",
+		"2023-01-08.txt": "Wrong extension.",
+	}
+	for name, content := range files {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644)
+		if err != nil {
+			t.Fatalf("failed writing %v: %v", name, err)
+		}
+	}
+
+	got, err := Dates(dir, time.UTC)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []time.Time{
+		time.Date(2022, time.December, 25, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, time.January, 6, 0, 0, 0, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want: %v, got: %v", want, got)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"git.sr.ht/~kota/calendar/month"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
 	"github.com/muesli/reflow/padding"
 	"github.com/muesli/reflow/wordwrap"
 	"github.com/muesli/reflow/wrap"
@@ -20,6 +21,9 @@ const (
 	MaxHeight       = month.MonthHeight*3 - BorderThickness/2
 )
 
+// zoneID is the id of the zone marking the preview for mouse events.
+const zoneID = "preview"
+
 // Preview is the Bubble Tea model for this preview element.
 type Preview struct {
 	config    *config.Config
@@ -62,17 +66,6 @@ func (p Preview) Update(msg tea.Msg) (Preview, tea.Cmd) {
 		case p.config.KeySelectDown.Contains(msg.String()):
 			p.LineDown(1)
 		}
-	case tea.MouseMsg:
-		switch msg.Type {
-		case tea.MouseWheelUp:
-			if p.isFocused {
-				p.LineUp(1)
-			}
-		case tea.MouseWheelDown:
-			if p.isFocused {
-				p.LineDown(1)
-			}
-		}
 	case tea.WindowSizeMsg:
 		p.setWidth(msg.Width)
 		p.setHeight(msg.Height)
@@ -211,9 +204,14 @@ func (p Preview) View() string {
 		extraLines = strings.Repeat("\n", max(0, p.height-len(visible)))
 	}
 
-	return p.style.Render(
-		strings.Join(visible, "\n") + extraLines,
-	)
+	return zone.Mark(zoneID, p.style.Render(
+		strings.Join(visible, "\n")+extraLines,
+	))
+}
+
+// InBounds reports if the mouse is over the preview.
+func (p Preview) InBounds(msg tea.MouseMsg) bool {
+	return zone.Get(zoneID).InBounds(msg)
 }
 
 // lines splits a long string into multiple lines using a max width and some
@@ -0,0 +1,119 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package prompt
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SubmitMsg is a tea.Msg returned when the user presses enter in a prompt.
+type SubmitMsg struct {
+	ID    string
+	Value string
+}
+
+// CancelMsg is a tea.Msg returned when the user presses escape in a prompt.
+type CancelMsg struct {
+	ID string
+}
+
+var errorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+
+// Prompt is the Bubble Tea model for a single line text input.
+type Prompt struct {
+	id     string
+	label  string
+	value  []rune
+	err    string
+	active bool
+}
+
+// New creates a new active prompt. The id is returned in SubmitMsg and
+// CancelMsg so the caller can tell which prompt was answered.
+func New(id, label string) Prompt {
+	return Prompt{
+		id:     id,
+		label:  label,
+		active: true,
+	}
+}
+
+// Init the prompt in Bubble Tea.
+func (p Prompt) Init() tea.Cmd {
+	return nil
+}
+
+// Updates the prompt in the Bubble Tea update loop.
+func (p Prompt) Update(msg tea.Msg) (Prompt, tea.Cmd) {
+	if !p.active {
+		return p, nil
+	}
+
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return p, nil
+	}
+
+	p.err = ""
+	switch key.Type {
+	case tea.KeyEnter:
+		p.active = false
+		id, value := p.id, string(p.value)
+		return p, func() tea.Msg {
+			return SubmitMsg{ID: id, Value: value}
+		}
+	case tea.KeyEsc, tea.KeyCtrlC:
+		p.active = false
+		id := p.id
+		return p, func() tea.Msg {
+			return CancelMsg{ID: id}
+		}
+	case tea.KeyBackspace:
+		if len(p.value) > 0 {
+			p.value = p.value[:len(p.value)-1]
+		}
+	case tea.KeyCtrlU:
+		p.value = nil
+	case tea.KeyRunes, tea.KeySpace:
+		p.value = append(p.value, key.Runes...)
+	}
+	return p, nil
+}
+
+// Active reports if the prompt is currently accepting input.
+func (p Prompt) Active() bool {
+	return p.active
+}
+
+// ID returns the id the prompt was created with.
+func (p Prompt) ID() string {
+	return p.id
+}
+
+// Reopen activates the prompt again, keeping the previous value, and displays
+// an error message. This is used when a submitted value was invalid.
+func (p Prompt) Reopen(err error) Prompt {
+	p.active = true
+	p.err = err.Error()
+	return p
+}
+
+// View renders the prompt in its current state.
+func (p Prompt) View() string {
+	if !p.active {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(p.label)
+	b.WriteString(string(p.value))
+	b.WriteString("█")
+	if p.err != "" {
+		b.WriteString(" ")
+		b.WriteString(errorStyle.Render(p.err))
+	}
+	return b.String()
+}
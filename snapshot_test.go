@@ -0,0 +1,462 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"git.sr.ht/~kota/calendar/calendar"
+	"git.sr.ht/~kota/calendar/clock"
+	"git.sr.ht/~kota/calendar/config"
+	"git.sr.ht/~kota/calendar/help"
+	"git.sr.ht/~kota/calendar/keyword"
+	"git.sr.ht/~kota/calendar/status"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+	"github.com/muesli/termenv"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// cmdTimeout is how long the harness waits for the commands returned by an
+// update. Commands which take longer, such as timers, are dropped.
+const cmdTimeout = 250 * time.Millisecond
+
+// zoneTimeout is how long the harness waits for the zones of a view to be
+// recorded before clicking one.
+const zoneTimeout = time.Second
+
+// ansiPattern matches the escape sequences used to style the view.
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*[A-Za-z]")
+
+// pointer is a step which sends a mouse event in the first cell of a zone, such
+// as "2025-03-9" for a day, "2025-03-heading" for a month's heading, or
+// "preview".
+type pointer struct {
+	zone string
+	typ  tea.MouseEventType
+}
+
+// click is a step which presses and releases the left mouse button in the
+// first cell of a zone.
+type click string
+
+// release is a step which releases the mouse button.
+var release = tea.MouseMsg{Type: tea.MouseRelease}
+
+// snapshotNotes are the notes written for every snapshot. The selected day's
+// note is long enough to scroll in the preview.
+var snapshotNotes = map[string]string{
+	"2025-03-14": "# Pi day\n\n" +
+		"Bake a pie for the office. Apples, cinnamon, and far too much " +
+		"butter.\n\n" +
+		"1. Buy apples\n\n2. Buy butter\n\n3. Make the crust\n\n" +
+		"4. Bake\n\n5. Eat\n\n6. Wash up\n\n7. Nap\n\n" +
+		"The last line of the note.\n",
+	"2025-03-20": "dentist at 9\n",
+	"2025-04-02": "Lunch with Alice\n",
+}
+
+// snapshotHolidays is the holiday list used for every snapshot.
+const snapshotHolidays = "@name snapshot\n" +
+	"2025-03-17 1 St. Patrick's Day\n" +
+	"2025-03-24..2025-03-26 5 Trip\n" +
+	"0000-12-25 2,bold Christmas\n"
+
+func TestSnapshots(t *testing.T) {
+	type test struct {
+		name  string
+		steps []tea.Msg
+	}
+
+	tests := []test{
+		{
+			name:  "one-month",
+			steps: []tea.Msg{size(100, 14)},
+		},
+		{
+			name:  "one-month-narrow",
+			steps: []tea.Msg{size(40, 14)},
+		},
+		{
+			name:  "three-months",
+			steps: []tea.Msg{size(100, 30)},
+		},
+		{
+			name: "three-months-moved",
+			steps: []tea.Msg{
+				size(100, 30),
+				key("l"),
+				key("j"),
+				key("e"),
+				key("ctrl+d"),
+			},
+		},
+		{
+			name:  "twelve-months",
+			steps: []tea.Msg{size(100, 30), key("p")},
+		},
+		{
+			name: "twelve-months-moved",
+			steps: []tea.Msg{
+				size(100, 30),
+				key("p"),
+				key("j"),
+				key("j"),
+				key("l"),
+			},
+		},
+		{
+			name:  "preview-focused",
+			steps: []tea.Msg{size(100, 30), key("tab")},
+		},
+		{
+			name: "preview-scrolled",
+			steps: []tea.Msg{
+				size(100, 14),
+				pointer{zone: "preview", typ: tea.MouseWheelDown},
+				pointer{zone: "preview", typ: tea.MouseWheelDown},
+				key("tab"),
+				key("j"),
+			},
+		},
+		{
+			name:  "preview-clicked",
+			steps: []tea.Msg{size(100, 30), click("preview")},
+		},
+		{
+			name:  "preview-hidden",
+			steps: []tea.Msg{size(80, 30), key("p")},
+		},
+		{
+			name:  "preview-other-day",
+			steps: []tea.Msg{size(100, 30), click("2025-03-20")},
+		},
+		{
+			name: "range",
+			steps: []tea.Msg{
+				size(100, 30),
+				key("v"),
+				key("j"),
+				key("l"),
+			},
+		},
+		{
+			name: "range-dragged",
+			steps: []tea.Msg{
+				size(100, 30),
+				pointer{zone: "2025-03-14", typ: tea.MouseLeft},
+				pointer{zone: "2025-03-18", typ: tea.MouseLeft},
+				pointer{zone: "2025-04-10", typ: tea.MouseLeft},
+				release,
+			},
+		},
+		{
+			name:  "heading-clicked",
+			steps: []tea.Msg{size(100, 30), click("2025-04-heading")},
+		},
+		{
+			name: "heading-scrolled",
+			steps: []tea.Msg{
+				size(100, 14),
+				pointer{zone: "2025-03-heading", typ: tea.MouseWheelUp},
+			},
+		},
+		{
+			name: "year-scrolled",
+			steps: []tea.Msg{
+				size(100, 30),
+				key("p"),
+				pointer{zone: "year", typ: tea.MouseWheelDown},
+			},
+		},
+		{
+			name: "twelve-months-clicked",
+			steps: []tea.Msg{
+				size(100, 30),
+				key("p"),
+				click("2025-10-heading"),
+				click("2025-10-31"),
+			},
+		},
+		{
+			name: "hover-keyword",
+			steps: []tea.Msg{
+				size(100, 30),
+				key("p"),
+				pointer{zone: "2025-03-20", typ: tea.MouseMotion},
+			},
+		},
+		{
+			name: "hover-holiday",
+			steps: []tea.Msg{
+				size(100, 30),
+				key("p"),
+				pointer{zone: "2025-03-25", typ: tea.MouseMotion},
+			},
+		},
+		{
+			name: "hover-note",
+			steps: []tea.Msg{
+				size(100, 14),
+				pointer{zone: "2025-03-14", typ: tea.MouseMotion},
+			},
+		},
+		{
+			name: "hover-cleared",
+			steps: []tea.Msg{
+				size(100, 14),
+				pointer{zone: "2025-03-20", typ: tea.MouseMotion},
+				key("l"),
+			},
+		},
+		{
+			name: "resized",
+			steps: []tea.Msg{
+				size(100, 30),
+				key("p"),
+				size(100, 14),
+				key("l"),
+			},
+		},
+		{
+			name:  "help",
+			steps: []tea.Msg{size(100, 30), key("?")},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := newHarness(t)
+			for _, step := range tc.steps {
+				h.send(step)
+			}
+			h.compare(tc.name)
+		})
+	}
+}
+
+// harness drives the program's model with synthetic messages, running the
+// commands it returns, so its view can be compared with golden files.
+type harness struct {
+	t     *testing.T
+	model tea.Model
+	// view is the last rendered view.
+	view string
+}
+
+// newHarness creates the program's model on 2025-03-14 with a clock frozen a
+// few days before, and with its notes, holidays, and caches in a temporary
+// directory.
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", filepath.Join(dir, "data"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(dir, "cache"))
+	for day, text := range snapshotNotes {
+		path := filepath.Join(dir, day+".md")
+		if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+			t.Fatalf("failed writing note: %v", err)
+		}
+	}
+	holidays := filepath.Join(dir, "holidays")
+	if err := os.WriteFile(holidays, []byte(snapshotHolidays), 0o644); err != nil {
+		t.Fatalf("failed writing holidays: %v", err)
+	}
+
+	// Render with colors regardless of the terminal running the tests.
+	lipgloss.SetColorProfile(termenv.ANSI)
+	lipgloss.SetHasDarkBackground(true)
+	zone.NewGlobal()
+
+	conf := config.Default()
+	conf.TimeZone = "UTC"
+	conf.NoteDir = dir
+	conf.HolidayLists = []string{holidays}
+	conf.Keywords = keyword.Keywords{{Keyword: "dentist", Color: "6"}}
+	loc := conf.Location()
+	clk := clock.Frozen(time.Date(2025, time.March, 10, 9, 30, 0, 0, loc))
+	selected := time.Date(2025, time.March, 14, 0, 0, 0, 0, loc)
+
+	h := &harness{
+		t: t,
+		model: model{
+			calendar: calendar.New(selected, conf, clk),
+			help:     help.New("snapshot", "en"),
+			status:   status.New(conf, nil, clk),
+			config:   conf,
+			clock:    clk,
+		},
+	}
+	h.settle(h.model.Init())
+	return h
+}
+
+// send a message to the model, run the commands it returns, and render the
+// view. A pointer or click is sent as mouse events on its zone.
+func (h *harness) send(msg tea.Msg) {
+	h.t.Helper()
+	switch step := msg.(type) {
+	case click:
+		h.send(pointer{zone: string(step), typ: tea.MouseLeft})
+		h.send(release)
+		return
+	case pointer:
+		msg = h.zoneMsg(step.zone, step.typ)
+	}
+	var cmd tea.Cmd
+	h.model, cmd = h.model.Update(msg)
+	h.settle(cmd)
+}
+
+// zoneMsg returns a mouse message of some type in the first cell of a zone. The
+// view is rendered again and the zone's position is waited for, as the zones
+// are recorded in the background.
+func (h *harness) zoneMsg(id string, typ tea.MouseEventType) tea.MouseMsg {
+	h.t.Helper()
+	zone.Clear(id)
+	h.render()
+	deadline := time.Now().Add(zoneTimeout)
+	for zone.Get(id).IsZero() {
+		if time.Now().After(deadline) {
+			h.t.Fatalf("zone %v was not rendered", id)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	z := zone.Get(id)
+	return tea.MouseMsg{X: z.StartX, Y: z.StartY, Type: typ}
+}
+
+// settle runs a command and feeds the messages it returns back into the model,
+// repeating until no more commands are returned.
+func (h *harness) settle(cmd tea.Cmd) {
+	for cmd != nil {
+		var cmds []tea.Cmd
+		for _, msg := range run(cmd) {
+			var next tea.Cmd
+			h.model, next = h.model.Update(msg)
+			cmds = append(cmds, next)
+		}
+		cmd = tea.Batch(cmds...)
+	}
+	h.render()
+}
+
+// render the model's view.
+func (h *harness) render() {
+	h.view = h.model.View()
+}
+
+// compare the view, with and without its escape sequences, to the golden files
+// named after a snapshot. The files are written instead when testing with
+// -update.
+func (h *harness) compare(name string) {
+	h.t.Helper()
+	files := map[string]string{
+		name + ".golden":      ansiPattern.ReplaceAllString(h.view, ""),
+		name + ".ansi.golden": h.view,
+	}
+	for file, got := range files {
+		path := filepath.Join("testdata", file)
+		if *update {
+			if err := os.MkdirAll("testdata", 0o755); err != nil {
+				h.t.Fatalf("failed creating testdata: %v", err)
+			}
+			if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+				h.t.Fatalf("failed writing golden file: %v", err)
+			}
+			continue
+		}
+		want, err := os.ReadFile(path)
+		if err != nil {
+			h.t.Fatalf("failed reading golden file: %v", err)
+		}
+		if got != string(want) {
+			h.t.Errorf(
+				"view does not match %v, run go test -update to accept it\n"+
+					"want:\n%v\ngot:\n%v",
+				path, string(want), got,
+			)
+		}
+	}
+}
+
+// run a command and any commands batched within it concurrently, returning
+// their messages in the order the commands were given. Commands which have not
+// returned within cmdTimeout are dropped.
+func run(cmd tea.Cmd) []tea.Msg {
+	return runUntil(cmd, time.Now().Add(cmdTimeout))
+}
+
+func runUntil(cmd tea.Cmd, deadline time.Time) []tea.Msg {
+	done := make(chan tea.Msg, 1)
+	go func() {
+		done <- cmd()
+	}()
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+
+	var msg tea.Msg
+	select {
+	case msg = <-done:
+	case <-timer.C:
+		return nil
+	}
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		if msg == nil {
+			return nil
+		}
+		return []tea.Msg{msg}
+	}
+
+	results := make([][]tea.Msg, len(batch))
+	var wg sync.WaitGroup
+	for i, c := range batch {
+		if c == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, c tea.Cmd) {
+			defer wg.Done()
+			results[i] = runUntil(c, deadline)
+		}(i, c)
+	}
+	wg.Wait()
+
+	var msgs []tea.Msg
+	for _, r := range results {
+		msgs = append(msgs, r...)
+	}
+	return msgs
+}
+
+// size returns a message resizing the window.
+func size(width, height int) tea.WindowSizeMsg {
+	return tea.WindowSizeMsg{Width: width, Height: height}
+}
+
+// key returns a message pressing a key, named as in the config, such as "l",
+// "tab", or "ctrl+d".
+func key(name string) tea.KeyMsg {
+	named := map[string]tea.KeyType{
+		"tab":    tea.KeyTab,
+		"enter":  tea.KeyEnter,
+		"esc":    tea.KeyEsc,
+		"ctrl+d": tea.KeyCtrlD,
+		"ctrl+u": tea.KeyCtrlU,
+		"ctrl+o": tea.KeyCtrlO,
+		"ctrl+n": tea.KeyCtrlN,
+	}
+	if t, ok := named[name]; ok {
+		return tea.KeyMsg{Type: t}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(name)}
+}
@@ -0,0 +1,251 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package status
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"git.sr.ht/~kota/calendar/astro"
+	"git.sr.ht/~kota/calendar/clock"
+	"git.sr.ht/~kota/calendar/config"
+	"git.sr.ht/~kota/calendar/date"
+	"git.sr.ht/~kota/calendar/date/system"
+	"git.sr.ht/~kota/calendar/locale"
+	"git.sr.ht/~kota/calendar/tz"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/truncate"
+)
+
+// MessageTimeout is how long a message is shown before it expires.
+const MessageTimeout = 4 * time.Second
+
+// messageMsg is a tea.Msg which shows a message in the status line.
+type messageMsg struct {
+	text  string
+	isErr bool
+}
+
+// expireMsg is a tea.Msg returned when a message has been shown for long
+// enough. The id is used to ignore expiry of messages that were already
+// replaced.
+type expireMsg struct{ id int }
+
+// Message returns a tea.Cmd which shows a message in the status line.
+func Message(format string, a ...any) tea.Cmd {
+	text := fmt.Sprintf(format, a...)
+	return func() tea.Msg {
+		return messageMsg{text: text}
+	}
+}
+
+// Error returns a tea.Cmd which shows an error in the status line. A nil error
+// returns a nil tea.Cmd.
+func Error(err error) tea.Cmd {
+	if err == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return messageMsg{text: err.Error(), isErr: true}
+	}
+}
+
+// clockMsg is a tea.Msg returned every minute to update the clocks of the
+// secondary time zones.
+type clockMsg time.Time
+
+// tick starts a timer which returns a clockMsg at the start of the minute
+// after now.
+func tick(now time.Time) tea.Cmd {
+	d := now.Truncate(time.Minute).Add(time.Minute).Sub(now)
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return clockMsg(t)
+	})
+}
+
+// Details describes the selected day for display in the status line.
+type Details struct {
+	Selected time.Time
+	Today    time.Time
+	Holiday  string
+	// Countdown describes the nearest upcoming countdown event.
+	Countdown string
+	// Range summarizes the selected range of days. When present it is shown
+	// instead of the selected day.
+	Range string
+	// Hover summarizes the day under the mouse, if any. When present it is
+	// shown instead of the selected day or range.
+	Hover *Hover
+}
+
+// Hover summarizes a day for the status line while the mouse rests on it.
+type Hover struct {
+	Day      time.Time
+	Holidays []string
+	// Keywords are the keywords found in the day's note.
+	Keywords []string
+	// Lines are the first lines of the day's note.
+	Lines []string
+}
+
+// Status is the Bubble Tea model for the status line.
+type Status struct {
+	config  *config.Config
+	zones   []tz.Zone
+	locale  locale.Locale
+	system  system.System
+	clock   clock.Clock
+	now     time.Time
+	message string
+	isErr   bool
+	id      int
+	width   int
+}
+
+// New creates a new status line model. The selected day in the configured
+// alternate calendar system and the current time in each of the zones,
+// according to the clock, are shown after the selected day.
+func New(conf *config.Config, zones []tz.Zone, clk clock.Clock) Status {
+	s, _ := system.Get(conf.AltCalendar)
+	clk = clock.Or(clk)
+	return Status{
+		config: conf,
+		zones:  zones,
+		locale: locale.Get(conf.Locale),
+		system: s,
+		clock:  clk,
+		now:    clk.Now(),
+	}
+}
+
+// Init the status line in Bubble Tea.
+func (s Status) Init() tea.Cmd {
+	if len(s.zones) == 0 {
+		return nil
+	}
+	return tick(s.now)
+}
+
+// Updates the status line in the Bubble Tea update loop.
+func (s Status) Update(msg tea.Msg) (Status, tea.Cmd) {
+	switch msg := msg.(type) {
+	case messageMsg:
+		s.message = msg.text
+		s.isErr = msg.isErr
+		s.id++
+		id := s.id
+		return s, tea.Tick(MessageTimeout, func(time.Time) tea.Msg {
+			return expireMsg{id: id}
+		})
+	case expireMsg:
+		if msg.id == s.id {
+			s.message = ""
+			s.isErr = false
+		}
+	case clockMsg:
+		s.now = s.clock.Now()
+		return s, tick(s.now)
+	case tea.WindowSizeMsg:
+		s.width = msg.Width
+	}
+	return s, nil
+}
+
+// View renders the status line for the given details.
+func (s Status) View(d Details) string {
+	var line string
+	style := s.config.StatusStyle.Export(lipgloss.NewStyle())
+	switch {
+	case s.message != "" && s.isErr:
+		line = s.message
+		style = s.config.ErrorStyle.Export(lipgloss.NewStyle())
+	case s.message != "":
+		line = s.message
+	case d.Hover != nil:
+		line = summarize(*d.Hover, s.locale)
+	case d.Range != "":
+		line = d.Range
+	default:
+		parts := []string{describe(d, s.locale)}
+		if s.system != nil {
+			parts = append(parts, s.system.Format(s.system.FromTime(d.Selected)))
+		}
+		if p := astro.MoonPhase(d.Selected); p != astro.NoPhase {
+			name := s.locale.Phases[p-astro.NewMoon]
+			parts = append(parts, p.Glyph()+" "+name)
+		}
+		if s.config.Latitude != 0 || s.config.Longitude != 0 {
+			parts = append(parts, daylight(astro.Sun(
+				d.Selected,
+				s.config.Latitude,
+				s.config.Longitude,
+			), s.locale))
+		}
+		for _, z := range s.zones {
+			parts = append(parts, z.Describe(s.now, d.Selected, s.locale))
+		}
+		line = strings.Join(parts, " · ")
+	}
+
+	line = strings.ReplaceAll(line, "\n", " ")
+	if s.width > 0 {
+		line = truncate.StringWithTail(line, uint(s.width), "…")
+	}
+	return style.Render(line)
+}
+
+// describe the selected day in long form.
+func describe(d Details, l locale.Locale) string {
+	_, week := d.Selected.ISOWeek()
+	parts := []string{
+		l.Format(d.Selected, l.LongDate),
+		fmt.Sprintf(l.DayOfYear, d.Selected.YearDay()),
+		fmt.Sprintf(l.Week, week),
+		l.Relative(date.Days(d.Today, d.Selected)),
+	}
+	if d.Holiday != "" {
+		parts = append(parts, d.Holiday)
+	}
+	if d.Countdown != "" {
+		parts = append(parts, d.Countdown)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// summarize a hovered day, such as
+// "Thursday, 20 March 2025 · Equinox · dentist · dentist at 9 / bring forms".
+func summarize(h Hover, l locale.Locale) string {
+	parts := []string{l.Format(h.Day, l.LongDate)}
+	if len(h.Holidays) > 0 {
+		parts = append(parts, strings.Join(h.Holidays, ", "))
+	}
+	if len(h.Keywords) > 0 {
+		parts = append(parts, strings.Join(h.Keywords, ", "))
+	}
+	if len(h.Lines) > 0 {
+		parts = append(parts, strings.Join(h.Lines, " / "))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// daylight describes the sunrise, sunset, and day length, such as
+// "sunrise 07:47 · sunset 16:58 · 9h11m daylight".
+func daylight(d astro.Daylight, l locale.Locale) string {
+	switch {
+	case d.PolarDay:
+		return l.MidnightSun
+	case d.PolarNight:
+		return l.PolarNight
+	}
+	length := d.Length.Round(time.Minute)
+	return strings.Join([]string{
+		fmt.Sprintf(l.Sunrise, d.Rise.Format("15:04")),
+		fmt.Sprintf(l.Sunset, d.Set.Format("15:04")),
+		fmt.Sprintf(
+			l.Daylight,
+			fmt.Sprintf("%vh%02dm", int(length.Hours()), int(length.Minutes())%60),
+		),
+	}, " · ")
+}
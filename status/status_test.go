@@ -0,0 +1,103 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package status
+
+import (
+	"testing"
+	"time"
+
+	"git.sr.ht/~kota/calendar/astro"
+	"git.sr.ht/~kota/calendar/locale"
+)
+
+func TestDescribe(t *testing.T) {
+	today := time.Date(2023, time.January, 2, 15, 4, 0, 0, time.UTC)
+
+	type test struct {
+		details Details
+		want    string
+	}
+
+	tests := []test{
+		{
+			details: Details{
+				Selected: today,
+				Today:    today,
+			},
+			want: "Monday, 2 January 2023 · day 2 · week 1 · today",
+		},
+		{
+			details: Details{
+				Selected: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+				Today:    today,
+				Holiday:  "New Year's Day",
+			},
+			want: "Sunday, 1 January 2023 · day 1 · week 52 · yesterday · New Year's Day",
+		},
+		{
+			details: Details{
+				Selected: time.Date(2023, time.December, 25, 0, 0, 0, 0, time.UTC),
+				Today:    today,
+			},
+			want: "Monday, 25 December 2023 · day 359 · week 52 · in 357 days",
+		},
+		{
+			details: Details{
+				Selected: time.Date(2022, time.December, 20, 0, 0, 0, 0, time.UTC),
+				Today:    today,
+			},
+			want: "Tuesday, 20 December 2022 · day 354 · week 51 · 13 days ago",
+		},
+	}
+
+	for _, tc := range tests {
+		got := describe(tc.details, locale.English)
+		if got != tc.want {
+			t.Fatalf("want: %q, got: %q", tc.want, got)
+		}
+	}
+
+	got := describe(tests[0].details, locale.Get("de_DE.UTF-8"))
+	want := "Montag, 2. Januar 2023 · Tag 2 · Woche 1 · heute"
+	if got != want {
+		t.Fatalf("want: %q, got: %q", want, got)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	day := time.Date(2025, time.March, 20, 0, 0, 0, 0, time.UTC)
+	h := Hover{
+		Day:      day,
+		Holidays: []string{"Equinox"},
+		Keywords: []string{"dentist"},
+		Lines:    []string{"dentist at 9", "bring forms"},
+	}
+	want := "Thursday, 20 March 2025 · Equinox · dentist · dentist at 9 / bring forms"
+	if got := summarize(h, locale.English); got != want {
+		t.Fatalf("want: %q, got: %q", want, got)
+	}
+	if got := summarize(Hover{Day: day}, locale.English); got != "Thursday, 20 March 2025" {
+		t.Fatalf("want only the date, got: %q", got)
+	}
+}
+
+func TestDaylight(t *testing.T) {
+	rise := time.Date(2023, time.June, 21, 7, 47, 10, 0, time.UTC)
+	set := time.Date(2023, time.June, 21, 16, 58, 40, 0, time.UTC)
+	d := astro.Daylight{Rise: rise, Set: set, Length: set.Sub(rise)}
+	got := daylight(d, locale.English)
+	want := "sunrise 07:47 · sunset 16:58 · 9h12m daylight"
+	if got != want {
+		t.Fatalf("want: %q, got: %q", want, got)
+	}
+	got = daylight(d, locale.Get("de"))
+	want = "Sonnenaufgang 07:47 · Sonnenuntergang 16:58 · 9h12m Tageslicht"
+	if got != want {
+		t.Fatalf("want: %q, got: %q", want, got)
+	}
+
+	polar := astro.Daylight{PolarNight: true}
+	if got := daylight(polar, locale.English); got != "polar night" {
+		t.Fatalf("want: %q, got: %q", "polar night", got)
+	}
+}
@@ -0,0 +1,68 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package tz
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"git.sr.ht/~kota/calendar/clock"
+	"git.sr.ht/~kota/calendar/config"
+	"git.sr.ht/~kota/calendar/holiday"
+	"git.sr.ht/~kota/calendar/locale"
+)
+
+// Zone is a secondary time zone with its own holidays.
+type Zone struct {
+	Name     string
+	Location *time.Location
+	Holidays holiday.Holidays
+}
+
+// Load creates the configured zones and reads their holiday lists, generating
+// builtin lists around the clock's current year. Holiday lists which fail to
+// parse are logged and the lines which did parse are used.
+func Load(zones []config.Zone, clk clock.Clock) ([]Zone, error) {
+	var loaded []Zone
+	for _, z := range zones {
+		location, err := time.LoadLocation(z.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TimeZone for %v: %v", z.Name, err)
+		}
+		name := z.Name
+		if name == "" {
+			name = z.TimeZone
+		}
+		holidays, err := holiday.Load(
+			z.HolidayLists,
+			holiday.Options{Location: location, Clock: clk},
+		)
+		if err != nil {
+			log.Printf("holidays for %v: %v\n", name, err)
+		}
+		loaded = append(loaded, Zone{
+			Name:     name,
+			Location: location,
+			Holidays: holidays,
+		})
+	}
+	return loaded, nil
+}
+
+// Describe the current time in the zone, such as "Berlin Tue 09:41", followed
+// by the holiday in this zone on the selected day, if any. Weekday names are
+// written in the given locale.
+func (z Zone) Describe(now, selected time.Time, l locale.Locale) string {
+	var b strings.Builder
+	b.WriteString(z.Name)
+	b.WriteString(" ")
+	b.WriteString(l.Format(now.In(z.Location), "Mon 15:04"))
+	if h, ok := z.Holidays.Match(selected); ok {
+		b.WriteString(" (")
+		b.WriteString(h.Message)
+		b.WriteString(")")
+	}
+	return b.String()
+}
@@ -0,0 +1,44 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package tz
+
+import (
+	"testing"
+	"time"
+
+	"git.sr.ht/~kota/calendar/holiday"
+	"git.sr.ht/~kota/calendar/locale"
+)
+
+func TestDescribe(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("failed loading Europe/Berlin timezone: %v", err)
+	}
+	z := Zone{
+		Name:     "Berlin",
+		Location: berlin,
+		Holidays: holiday.Holidays{
+			{Date: "0000-10-03", Message: "Tag der Deutschen Einheit"},
+		},
+	}
+
+	auckland, err := time.LoadLocation("Pacific/Auckland")
+	if err != nil {
+		t.Fatalf("failed loading Pacific/Auckland timezone: %v", err)
+	}
+	// Tuesday morning in Auckland is still Monday evening in Berlin.
+	now := time.Date(2023, time.October, 3, 8, 30, 0, 0, auckland)
+
+	got := z.Describe(now, now, locale.English)
+	want := "Berlin Mon 21:30 (Tag der Deutschen Einheit)"
+	if got != want {
+		t.Fatalf("want: %q, got: %q", want, got)
+	}
+
+	got = z.Describe(now, now.AddDate(0, 0, 1), locale.English)
+	want = "Berlin Mon 21:30"
+	if got != want {
+		t.Fatalf("want: %q, got: %q", want, got)
+	}
+}
@@ -0,0 +1,93 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package workday
+
+import (
+	"time"
+
+	"git.sr.ht/~kota/calendar/config"
+	"git.sr.ht/~kota/calendar/holiday"
+)
+
+// maxSearch is the number of days we are willing to search for a business day
+// before giving up. This avoids looping forever when every day is a day off.
+const maxSearch = 3660
+
+// Schedule describes which days are business days.
+type Schedule struct {
+	Weekend  config.Weekdays
+	Holidays holiday.Holidays
+}
+
+// New creates a new schedule.
+func New(weekend config.Weekdays, holidays holiday.Holidays) Schedule {
+	return Schedule{
+		Weekend:  weekend,
+		Holidays: holidays,
+	}
+}
+
+// Off reports if a given time is a weekend day or a holiday marked as a day
+// off.
+func (s Schedule) Off(t time.Time) bool {
+	return s.Weekend.Contains(t.Weekday()) || s.Holidays.DayOff(t)
+}
+
+// Next returns the next business day after time t.
+func (s Schedule) Next(t time.Time) time.Time {
+	return s.Add(t, 1)
+}
+
+// Last returns the last business day before time t.
+func (s Schedule) Last(t time.Time) time.Time {
+	return s.Add(t, -1)
+}
+
+// Add returns the time n business days after time t. A negative n counts
+// backwards. If no business day can be found t is returned unchanged.
+func (s Schedule) Add(t time.Time, n int) time.Time {
+	step := 1
+	if n < 0 {
+		step = -1
+		n = -n
+	}
+
+	day := t
+	for i := 0; n > 0; i++ {
+		if i >= maxSearch {
+			return t
+		}
+		day = time.Date(
+			day.Year(),
+			day.Month(),
+			day.Day()+step,
+			0, 0, 0, 0,
+			day.Location(),
+		)
+		if !s.Off(day) {
+			n--
+		}
+	}
+	return day
+}
+
+// Count returns the number of business days after from, up to and including
+// to. The result is negative if to is before from.
+func (s Schedule) Count(from, to time.Time) int {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	sign := 1
+	if to.Before(from) {
+		from, to = to, from
+		sign = -1
+	}
+
+	var n int
+	for day := from.AddDate(0, 0, 1); !day.After(to); day = day.AddDate(0, 0, 1) {
+		if !s.Off(day) {
+			n++
+		}
+	}
+	return sign * n
+}
@@ -0,0 +1,140 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package workday
+
+import (
+	"testing"
+	"time"
+
+	"git.sr.ht/~kota/calendar/config"
+	"git.sr.ht/~kota/calendar/holiday"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestAdd(t *testing.T) {
+	s := New(
+		config.Weekdays{"Saturday", "Sun"},
+		holiday.Holidays{
+			{Date: "0000-12-25", Message: "Christmas", DayOff: true},
+			{Date: "0000-12-24", Message: "Christmas Eve"},
+		},
+	)
+
+	type test struct {
+		description string
+		from        time.Time
+		n           int
+		want        time.Time
+	}
+
+	tests := []test{
+		{
+			description: "next day mid week",
+			from:        day(2023, time.January, 3),
+			n:           1,
+			want:        day(2023, time.January, 4),
+		},
+		{
+			description: "skip the weekend",
+			from:        day(2023, time.January, 6),
+			n:           1,
+			want:        day(2023, time.January, 9),
+		},
+		{
+			description: "backwards over the weekend",
+			from:        day(2023, time.January, 9),
+			n:           -1,
+			want:        day(2023, time.January, 6),
+		},
+		{
+			description: "ten business days",
+			from:        day(2023, time.January, 2),
+			n:           10,
+			want:        day(2023, time.January, 16),
+		},
+		{
+			description: "skip a day off but not a regular holiday",
+			from:        day(2024, time.December, 23),
+			n:           2,
+			want:        day(2024, time.December, 26),
+		},
+		{
+			description: "zero is unchanged",
+			from:        day(2023, time.January, 7),
+			n:           0,
+			want:        day(2023, time.January, 7),
+		},
+	}
+
+	for _, tc := range tests {
+		got := s.Add(tc.from, tc.n)
+		if !got.Equal(tc.want) {
+			t.Fatalf(
+				"got: %v, want: %v, for: %v\n",
+				got,
+				tc.want,
+				tc.description,
+			)
+		}
+	}
+}
+
+func TestAddAllDaysOff(t *testing.T) {
+	s := New(config.Weekdays{
+		"Sunday", "Monday", "Tuesday", "Wednesday",
+		"Thursday", "Friday", "Saturday",
+	}, nil)
+	from := day(2023, time.January, 3)
+	if got := s.Add(from, 1); !got.Equal(from) {
+		t.Fatalf("got: %v, want: %v\n", got, from)
+	}
+}
+
+func TestCount(t *testing.T) {
+	s := New(config.Weekdays{"Saturday", "Sunday"}, nil)
+
+	type test struct {
+		from time.Time
+		to   time.Time
+		want int
+	}
+
+	tests := []test{
+		{
+			from: day(2023, time.January, 2),
+			to:   day(2023, time.January, 2),
+			want: 0,
+		},
+		{
+			from: day(2023, time.January, 2),
+			to:   day(2023, time.January, 16),
+			want: 10,
+		},
+		{
+			from: day(2023, time.January, 16),
+			to:   day(2023, time.January, 2),
+			want: -10,
+		},
+		{
+			from: day(2023, time.January, 6),
+			to:   day(2023, time.January, 8),
+			want: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		got := s.Count(tc.from, tc.to)
+		if got != tc.want {
+			t.Fatalf(
+				"input:\n%v\n%v\nwant: %v, got: %v\n",
+				tc.from,
+				tc.to,
+				tc.want,
+				got,
+			)
+		}
+	}
+}
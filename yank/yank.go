@@ -0,0 +1,159 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package yank
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"git.sr.ht/~kota/calendar/config"
+	"git.sr.ht/~kota/calendar/locale"
+	"git.sr.ht/~kota/calendar/note"
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// screenChunk is the longest part of an escape sequence wrapped in each of
+// screen's passthrough strings, which it limits in length.
+const screenChunk = 76
+
+// Format renders a time using a yank format. Month and weekday names are
+// written in the given locale.
+func Format(t time.Time, f config.YankFormat, l locale.Locale, dir string) string {
+	switch f.Format {
+	case "RFC3339":
+		return t.Format(time.RFC3339)
+	case "Unix":
+		return strconv.FormatInt(t.Unix(), 10)
+	case "Content":
+		return note.Load(t, dir)
+	case "Path":
+		return note.Path(t, dir)
+	default:
+		return l.Format(t, f.Format)
+	}
+}
+
+// FormatRange renders a range of days using a yank format. Date formats are
+// written as first..last while note contents and paths are written for each
+// day in the range which has a note.
+func FormatRange(
+	first, last time.Time,
+	f config.YankFormat,
+	l locale.Locale,
+	dir string,
+) string {
+	switch f.Format {
+	case "Content":
+		return note.LoadRange(first, last, dir)
+	case "Path":
+		var paths []string
+		for t := first; !t.After(last); t = t.AddDate(0, 0, 1) {
+			if note.Exists(t, dir) {
+				paths = append(paths, note.Path(t, dir))
+			}
+		}
+		return strings.Join(paths, "\n")
+	default:
+		return Format(first, f, l, dir) + ".." + Format(last, f, l, dir)
+	}
+}
+
+// Copy writes a string to the clipboard.
+//
+// The target may be "system" to use the system clipboard, "osc52" to ask the
+// terminal to set the clipboard with an OSC 52 escape sequence (which works
+// over SSH and inside tmux), or "both". The default, "auto", uses OSC 52 in an
+// SSH session or when no system clipboard is available and falls back to it
+// if the system clipboard fails.
+//
+// Writing the escape sequence straight to standard output would race the
+// Bubble Tea renderer, so it is returned as a tea.ExecCommand to be run with
+// tea.Exec. The command is nil when OSC 52 isn't used.
+func Copy(s string, target string) (tea.ExecCommand, error) {
+	switch target {
+	case "system":
+		return nil, clipboard.WriteAll(s)
+	case "osc52":
+		return copyOSC52(s), nil
+	case "both":
+		if err := clipboard.WriteAll(s); err != nil {
+			return nil, err
+		}
+		return copyOSC52(s), nil
+	case "auto", "":
+		if clipboard.Unsupported || remote() {
+			return copyOSC52(s), nil
+		}
+		if err := clipboard.WriteAll(s); err != nil {
+			return copyOSC52(s), nil
+		}
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown clipboard %v", target)
+	}
+}
+
+// copyOSC52 returns a command asking the terminal to set the clipboard with an
+// OSC 52 escape sequence.
+func copyOSC52(s string) tea.ExecCommand {
+	return &osc52Command{seq: osc52(s, os.Getenv), stdout: os.Stdout}
+}
+
+// osc52Command writes an OSC 52 escape sequence to the terminal while the
+// program is paused by tea.Exec.
+type osc52Command struct {
+	seq    string
+	stdout io.Writer
+}
+
+// Run writes the escape sequence.
+func (c *osc52Command) Run() error {
+	_, err := io.WriteString(c.stdout, c.seq)
+	return err
+}
+
+// SetStdin does nothing as the escape sequence has no reply.
+func (c *osc52Command) SetStdin(io.Reader) {}
+
+// SetStdout sets the terminal the escape sequence is written to.
+func (c *osc52Command) SetStdout(w io.Writer) {
+	c.stdout = w
+}
+
+// SetStderr does nothing as nothing is written to standard error.
+func (c *osc52Command) SetStderr(io.Writer) {}
+
+// osc52 returns the OSC 52 escape sequence setting the clipboard to s. Inside
+// tmux or screen it is wrapped in their DCS passthrough sequences so it reaches
+// the outer terminal rather than being swallowed.
+func osc52(s string, getenv func(string) string) string {
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(s)) + "\x07"
+	switch {
+	case getenv("TMUX") != "":
+		// Escapes inside the passthrough are doubled.
+		return "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	case getenv("STY") != "" || strings.HasPrefix(getenv("TERM"), "screen"):
+		var b strings.Builder
+		for len(seq) > 0 {
+			n := screenChunk
+			if len(seq) < n {
+				n = len(seq)
+			}
+			b.WriteString("\x1bP" + seq[:n] + "\x1b\\")
+			seq = seq[n:]
+		}
+		return b.String()
+	}
+	return seq
+}
+
+// remote reports if we're running in an SSH session.
+func remote() bool {
+	return os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != ""
+}
@@ -0,0 +1,147 @@
+// License: GPL-3.0-only
+// (c) 2022 Dakota Walsh <kota@nilsu.org>
+package yank
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"git.sr.ht/~kota/calendar/config"
+	"git.sr.ht/~kota/calendar/locale"
+)
+
+func TestFormat(t *testing.T) {
+	dir := t.TempDir()
+	day := time.Date(2023, time.January, 6, 0, 0, 0, 0, time.UTC)
+	err := os.WriteFile(
+		filepath.Join(dir, "2023-01-06.md"),
+		[]byte("Dentist at 3pm."),
+		0o644,
+	)
+	if err != nil {
+		t.Fatalf("failed writing note: %v", err)
+	}
+
+	type test struct {
+		format string
+		want   string
+	}
+
+	tests := []test{
+		{format: "2006-01-02", want: "2023-01-06"},
+		{format: "Mon 2 Jan", want: "Fri 6 Jan"},
+		{format: "RFC3339", want: "2023-01-06T00:00:00Z"},
+		{format: "Unix", want: "1672963200"},
+		{format: "Content", want: "Dentist at 3pm."},
+		{format: "Path", want: filepath.Join(dir, "2023-01-06.md")},
+	}
+
+	for _, tc := range tests {
+		got := Format(
+			day,
+			config.YankFormat{Format: tc.format},
+			locale.English,
+			dir,
+		)
+		if got != tc.want {
+			t.Fatalf("format: %v, want: %q, got: %q", tc.format, tc.want, got)
+		}
+	}
+
+	got := Format(
+		day,
+		config.YankFormat{Format: "Mon 2 Jan"},
+		locale.Get("de"),
+		dir,
+	)
+	if want := "Fr 6 Jan"; got != want {
+		t.Fatalf("want: %q, got: %q", want, got)
+	}
+}
+
+func TestFormatRange(t *testing.T) {
+	first := time.Date(2023, time.January, 6, 0, 0, 0, 0, time.UTC)
+	last := time.Date(2023, time.January, 9, 0, 0, 0, 0, time.UTC)
+	got := FormatRange(
+		first,
+		last,
+		config.YankFormat{Format: "2006-01-02"},
+		locale.English,
+		t.TempDir(),
+	)
+	if want := "2023-01-06..2023-01-09"; got != want {
+		t.Fatalf("want: %q, got: %q", want, got)
+	}
+}
+
+func TestOSC52(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+	// "2023-01-06" is "MjAyMy0wMS0wNg==" in base64.
+	plain := "\x1b]52;c;MjAyMy0wMS0wNg==\x07"
+
+	type test struct {
+		description string
+		env         map[string]string
+		want        string
+	}
+
+	tests := []test{
+		{description: "plain terminal", want: plain},
+		{
+			description: "tmux",
+			env:         map[string]string{"TMUX": "/tmp/tmux-1000/default,1,0", "TERM": "screen"},
+			want:        "\x1bPtmux;\x1b\x1b]52;c;MjAyMy0wMS0wNg==\x07\x1b\\",
+		},
+		{
+			description: "screen",
+			env:         map[string]string{"STY": "1.pts-0.host"},
+			want:        "\x1bP" + plain + "\x1b\\",
+		},
+	}
+
+	for _, tc := range tests {
+		if got := osc52("2023-01-06", env(tc.env)); got != tc.want {
+			t.Fatalf("%v: want: %q, got: %q", tc.description, tc.want, got)
+		}
+	}
+
+	// Long sequences are split across several of screen's passthrough strings.
+	got := osc52(strings.Repeat("x", 200), env(map[string]string{"TERM": "screen"}))
+	if n := strings.Count(got, "\x1bP"); n != 4 {
+		t.Fatalf("want 4 passthrough strings, got: %v in %q", n, got)
+	}
+	got = strings.NewReplacer("\x1bP", "", "\x1b\\", "").Replace(got)
+	if want := osc52(strings.Repeat("x", 200), env(nil)); got != want {
+		t.Fatalf("want the sequence split unchanged: %q, got: %q", want, got)
+	}
+}
+
+func TestCopyOSC52(t *testing.T) {
+	t.Setenv("TMUX", "")
+	t.Setenv("STY", "")
+	t.Setenv("TERM", "xterm")
+
+	// The escape sequence is only written to the output handed to the command
+	// by tea.Exec.
+	cmd, err := Copy("2023-01-06", "osc52")
+	if err != nil || cmd == nil {
+		t.Fatalf("want an OSC 52 command, got: %v, %v", cmd, err)
+	}
+	var b strings.Builder
+	cmd.SetStdout(&b)
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("failed running the command: %v", err)
+	}
+	if want := "\x1b]52;c;MjAyMy0wMS0wNg==\x07"; b.String() != want {
+		t.Fatalf("want: %q, got: %q", want, b.String())
+	}
+
+	if _, err := Copy("2023-01-06", "nowhere"); err == nil {
+		t.Fatalf("expected an error for an unknown clipboard")
+	}
+}